@@ -0,0 +1,59 @@
+package roarindex
+
+import (
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// BitmapOf returns a copy of the bitmap of value IDs associated with key,
+// which the caller may modify freely. Translate value IDs back with
+// ValueByID or ValuesOf.
+func (om *RoarIndex[K, V]) BitmapOf(key K) (*roaring.Bitmap, error) {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil {
+		return nil, err
+	}
+	return bm.Clone(), nil
+}
+
+// Cardinality returns the number of values associated with key, 0 when key
+// is not in the RoarIndex, without building the values.
+func (om *RoarIndex[K, V]) Cardinality(key K) int {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil {
+		return 0
+	}
+	return int(bm.GetCardinality())
+}
+
+// ValueID returns the internal ID of value, and false when the RoarIndex
+// does not know the value.
+func (om *RoarIndex[K, V]) ValueID(value V) (uint32, bool) {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	return om.backend.ValueID(value)
+}
+
+// ValueByID returns the value with the internal ID id, and false when there
+// is none.
+func (om *RoarIndex[K, V]) ValueByID(id uint32) (V, bool) {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	return om.backend.Value(id)
+}
+
+// ValuesOf translates a bitmap of value IDs, such as one derived from
+// BitmapOf, to values. IDs without a value are skipped.
+func (om *RoarIndex[K, V]) ValuesOf(bm *roaring.Bitmap) []V {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	return om.valuesOf(bm)
+}
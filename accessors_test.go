@@ -0,0 +1,57 @@
+package roarindex
+
+import (
+	"reflect"
+	"testing"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+func TestRoarIndexBitmapAccessors(t *testing.T) {
+	om := NewRoarIndex[string, string]()
+	om.PushMap("map1", "value1")
+	om.PushMap("map1", "value2")
+	om.PushMap("map2", "value2")
+	om.PushMap("map2", "value3")
+
+	bm1, err := om.BitmapOf("map1")
+	if err != nil {
+		t.Fatalf("BitmapOf failed: %v", err)
+	}
+	bm2, err := om.BitmapOf("map2")
+	if err != nil {
+		t.Fatalf("BitmapOf failed: %v", err)
+	}
+
+	// Bitmap algebra outside the index, translated back to values
+	if values := om.ValuesOf(roaring.And(bm1, bm2)); !reflect.DeepEqual(values, []string{"value2"}) {
+		t.Errorf("Expected [value2], but got %v", values)
+	}
+
+	// Modifying the copy must not change the index
+	bm1.Clear()
+	if !om.HasValue("map1", "value1") {
+		t.Errorf("Expected BitmapOf to return a copy")
+	}
+
+	if _, err := om.BitmapOf("nonExistent"); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, but got %v", err)
+	}
+
+	id, ok := om.ValueID("value3")
+	if !ok || !bm2.Contains(id) {
+		t.Errorf("Expected the ID of value3 to be in the bitmap of map2")
+	}
+	if value, ok := om.ValueByID(id); !ok || value != "value3" {
+		t.Errorf("Expected ValueByID to return value3, but got %q", value)
+	}
+	if _, ok := om.ValueID("nonExistent"); ok {
+		t.Errorf("Expected no ID for an unknown value")
+	}
+	if _, ok := om.ValueByID(1000); ok {
+		t.Errorf("Expected no value for an unknown ID")
+	}
+	if values := om.ValuesOf(roaring.BitmapOf(1000)); len(values) != 0 {
+		t.Errorf("Expected unknown IDs to be skipped, but got %v", values)
+	}
+}
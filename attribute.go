@@ -0,0 +1,84 @@
+package roarindex
+
+import (
+	"errors"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// ErrForeignAttribute is returned when an Attribute is used with another
+// RoarIndex than the one it was registered with.
+var ErrForeignAttribute = errors.New("attribute registered with another index")
+
+// valueIndex is a secondary index on values, such as an Attribute, that
+// the RoarIndex keeps up to date.
+type valueIndex[V comparable] interface {
+	addValue(valueID uint32, value V)
+}
+
+// Attribute indexes the values of a RoarIndex by an attribute extracted
+// from each value, such as a struct field, keeping a bitmap of value IDs per
+// attribute value.
+type Attribute[K comparable, V comparable, A comparable] struct {
+	om      *RoarIndex[K, V]
+	extract func(V) A
+
+	// Value IDs per attribute value, guarded by the lock of om
+	bitmaps map[A]*roaring.Bitmap
+}
+
+// RegisterAttribute starts indexing the values of om by the attribute
+// extract returns, which must always return the same attribute for the same
+// value. Existing values are indexed right away.
+func RegisterAttribute[K comparable, V comparable, A comparable](om *RoarIndex[K, V], extract func(V) A) *Attribute[K, V, A] {
+	attr := &Attribute[K, V, A]{
+		om:      om,
+		extract: extract,
+		bitmaps: make(map[A]*roaring.Bitmap),
+	}
+
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	om.backend.RangeValues(func(value V, valueID uint32) bool {
+		attr.addValue(valueID, value)
+		return true
+	})
+	om.valueIndexes = append(om.valueIndexes, attr)
+	return attr
+}
+
+// addValue indexes a new value. The caller must hold the write lock.
+func (attr *Attribute[K, V, A]) addValue(valueID uint32, value V) {
+	a := attr.extract(value)
+	bm, exists := attr.bitmaps[a]
+	if !exists {
+		bm = roaring.NewBitmap()
+		attr.bitmaps[a] = bm
+	}
+	bm.Add(valueID)
+}
+
+// GetMapWhere retrieves the values associated with key whose attribute
+// equals a, by intersecting the bitmap of key with the bitmap of a. Values
+// are in GetMap order.
+func GetMapWhere[K comparable, V comparable, A comparable](om *RoarIndex[K, V], key K, attr *Attribute[K, V, A], a A) ([]V, error) {
+	if attr.om != om {
+		return nil, ErrForeignAttribute
+	}
+
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil {
+		return nil, err
+	}
+	matching, exists := attr.bitmaps[a]
+	if !exists {
+		return []V{}, nil
+	}
+	values := om.valuesOf(roaring.And(bm, matching))
+	om.sortValues(values)
+	return values, nil
+}
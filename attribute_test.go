@@ -0,0 +1,94 @@
+package roarindex
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type attributeTestItem struct {
+	Name  string
+	Color string
+	Size  int
+}
+
+func TestRoarIndexGetMapWhere(t *testing.T) {
+	om := NewRoarIndex[string, attributeTestItem]()
+	om.PushMap("box1", attributeTestItem{"apple", "red", 1})
+	om.PushMap("box1", attributeTestItem{"pear", "green", 2})
+
+	// Registering indexes the existing values
+	color := RegisterAttribute(om, func(item attributeTestItem) string { return item.Color })
+	size := RegisterAttribute(om, func(item attributeTestItem) int { return item.Size })
+
+	// New values are indexed as they are pushed
+	om.PushMap("box1", attributeTestItem{"cherry", "red", 1})
+	om.PushMap("box2", attributeTestItem{"strawberry", "red", 1})
+
+	names := func(items []attributeTestItem) []string {
+		var names []string
+		for _, item := range items {
+			names = append(names, item.Name)
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	red, err := GetMapWhere(om, "box1", color, "red")
+	if err != nil {
+		t.Fatalf("GetMapWhere failed: %v", err)
+	}
+	if !reflect.DeepEqual(names(red), []string{"apple", "cherry"}) {
+		t.Errorf("Expected [apple cherry], but got %v", names(red))
+	}
+
+	large, err := GetMapWhere(om, "box1", size, 2)
+	if err != nil {
+		t.Fatalf("GetMapWhere failed: %v", err)
+	}
+	if !reflect.DeepEqual(names(large), []string{"pear"}) {
+		t.Errorf("Expected [pear], but got %v", names(large))
+	}
+
+	if blue, err := GetMapWhere(om, "box1", color, "blue"); err != nil || len(blue) != 0 {
+		t.Errorf("Expected no blue values, but got %v and %v", blue, err)
+	}
+	if _, err := GetMapWhere(om, "nonExistent", color, "red"); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, but got %v", err)
+	}
+
+	// Values follow the order of GetMap
+	om.SetOrderFunc(nil, func(a, b attributeTestItem) int { return cmp.Compare(b.Name, a.Name) })
+	if red, _ := GetMapWhere(om, "box1", color, "red"); len(red) != 2 || red[0].Name != "cherry" {
+		t.Errorf("Expected [cherry apple], but got %v", red)
+	}
+
+	other := NewRoarIndex[string, attributeTestItem]()
+	if _, err := GetMapWhere(other, "box1", color, "red"); err != ErrForeignAttribute {
+		t.Errorf("Expected ErrForeignAttribute, but got %v", err)
+	}
+}
+
+func TestRoarIndexAttributeAfterMerge(t *testing.T) {
+	om := NewRoarIndex[string, string]()
+	initial := RegisterAttribute(om, func(v string) byte { return v[0] })
+
+	other := NewRoarIndex[string, string]()
+	other.PushMap("key1", "apple")
+	other.PushMap("key1", "banana")
+	other.PushMap("key1", "avocado")
+	if err := om.Merge(other, MergeUnion); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	values, err := GetMapWhere(om, "key1", initial, 'a')
+	if err != nil {
+		t.Fatalf("GetMapWhere failed: %v", err)
+	}
+	slices.SortFunc(values, strings.Compare)
+	if !reflect.DeepEqual(values, []string{"apple", "avocado"}) {
+		t.Errorf("Expected [apple avocado], but got %v", values)
+	}
+}
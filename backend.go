@@ -0,0 +1,174 @@
+package roarindex
+
+import (
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// Backend stores the bitmaps and the key and value dictionaries of a
+// RoarIndex.
+//
+// The RoarIndex guards its backend with its own lock: read methods may be
+// called concurrently with each other, write methods are never called
+// concurrently with any other method. Bitmaps handed out by Bitmap and
+// RangeBitmaps may be modified by the RoarIndex, which always stores them
+// back with PutBitmap afterwards. Range callbacks must not modify the
+// backend and stop the iteration by returning false.
+type Backend[K comparable, V comparable] interface {
+	// Bitmap returns the bitmap of value IDs stored for a key ID.
+	Bitmap(keyID uint32) (*roaring.Bitmap, bool)
+	// PutBitmap stores the bitmap for a key ID, replacing any previous one.
+	PutBitmap(keyID uint32, bm *roaring.Bitmap)
+	// DeleteBitmap removes the bitmap of a key ID.
+	DeleteBitmap(keyID uint32)
+	// RangeBitmaps calls fn for every stored bitmap.
+	RangeBitmaps(fn func(keyID uint32, bm *roaring.Bitmap) bool)
+
+	// KeyID returns the ID assigned to a key.
+	KeyID(key K) (uint32, bool)
+	// Key returns the key assigned to an ID.
+	Key(keyID uint32) (K, bool)
+	// PutKey assigns an ID to a key.
+	PutKey(key K, keyID uint32)
+	// DeleteKey removes a key and its ID.
+	DeleteKey(key K, keyID uint32)
+	// RangeKeys calls fn for every key and its ID.
+	RangeKeys(fn func(key K, keyID uint32) bool)
+	// KeyCount returns the number of keys.
+	KeyCount() int
+
+	// ValueID returns the ID assigned to a value.
+	ValueID(value V) (uint32, bool)
+	// Value returns the value assigned to an ID.
+	Value(valueID uint32) (V, bool)
+	// PutValue assigns an ID to a value.
+	PutValue(value V, valueID uint32)
+	// RangeValues calls fn for every value and its ID.
+	RangeValues(fn func(value V, valueID uint32) bool)
+	// ValueCount returns the number of values.
+	ValueCount() int
+}
+
+var _ Backend[string, string] = (*MemoryBackend[string, string])(nil)
+
+// MemoryBackend is the default Backend, keeping everything in Go maps.
+type MemoryBackend[K comparable, V comparable] struct {
+	// Maps to assign unique IDs to keys and values
+	keyToID   map[K]uint32
+	idToKey   map[uint32]K
+	valueToID map[V]uint32
+	idToValue map[uint32]V
+
+	// Map from key IDs to RoaringBitmap of value IDs
+	data map[uint32]*roaring.Bitmap
+}
+
+// NewMemoryBackend creates a new, empty MemoryBackend.
+func NewMemoryBackend[K comparable, V comparable]() *MemoryBackend[K, V] {
+	return &MemoryBackend[K, V]{
+		keyToID:   make(map[K]uint32),
+		idToKey:   make(map[uint32]K),
+		valueToID: make(map[V]uint32),
+		idToValue: make(map[uint32]V),
+		data:      make(map[uint32]*roaring.Bitmap),
+	}
+}
+
+// empty reports whether mb holds no keys, values or bitmaps.
+func (mb *MemoryBackend[K, V]) empty() bool {
+	return len(mb.keyToID) == 0 && len(mb.valueToID) == 0 && len(mb.data) == 0
+}
+
+// Bitmap implements Backend.
+func (mb *MemoryBackend[K, V]) Bitmap(keyID uint32) (*roaring.Bitmap, bool) {
+	bm, exists := mb.data[keyID]
+	return bm, exists
+}
+
+// PutBitmap implements Backend.
+func (mb *MemoryBackend[K, V]) PutBitmap(keyID uint32, bm *roaring.Bitmap) {
+	mb.data[keyID] = bm
+}
+
+// DeleteBitmap implements Backend.
+func (mb *MemoryBackend[K, V]) DeleteBitmap(keyID uint32) {
+	delete(mb.data, keyID)
+}
+
+// RangeBitmaps implements Backend.
+func (mb *MemoryBackend[K, V]) RangeBitmaps(fn func(keyID uint32, bm *roaring.Bitmap) bool) {
+	for keyID, bm := range mb.data {
+		if !fn(keyID, bm) {
+			return
+		}
+	}
+}
+
+// KeyID implements Backend.
+func (mb *MemoryBackend[K, V]) KeyID(key K) (uint32, bool) {
+	keyID, exists := mb.keyToID[key]
+	return keyID, exists
+}
+
+// Key implements Backend.
+func (mb *MemoryBackend[K, V]) Key(keyID uint32) (K, bool) {
+	key, exists := mb.idToKey[keyID]
+	return key, exists
+}
+
+// PutKey implements Backend.
+func (mb *MemoryBackend[K, V]) PutKey(key K, keyID uint32) {
+	mb.keyToID[key] = keyID
+	mb.idToKey[keyID] = key
+}
+
+// DeleteKey implements Backend.
+func (mb *MemoryBackend[K, V]) DeleteKey(key K, keyID uint32) {
+	delete(mb.keyToID, key)
+	delete(mb.idToKey, keyID)
+}
+
+// RangeKeys implements Backend.
+func (mb *MemoryBackend[K, V]) RangeKeys(fn func(key K, keyID uint32) bool) {
+	for key, keyID := range mb.keyToID {
+		if !fn(key, keyID) {
+			return
+		}
+	}
+}
+
+// KeyCount implements Backend.
+func (mb *MemoryBackend[K, V]) KeyCount() int {
+	return len(mb.keyToID)
+}
+
+// ValueID implements Backend.
+func (mb *MemoryBackend[K, V]) ValueID(value V) (uint32, bool) {
+	valueID, exists := mb.valueToID[value]
+	return valueID, exists
+}
+
+// Value implements Backend.
+func (mb *MemoryBackend[K, V]) Value(valueID uint32) (V, bool) {
+	value, exists := mb.idToValue[valueID]
+	return value, exists
+}
+
+// PutValue implements Backend.
+func (mb *MemoryBackend[K, V]) PutValue(value V, valueID uint32) {
+	mb.valueToID[value] = valueID
+	mb.idToValue[valueID] = value
+}
+
+// RangeValues implements Backend.
+func (mb *MemoryBackend[K, V]) RangeValues(fn func(value V, valueID uint32) bool) {
+	for value, valueID := range mb.valueToID {
+		if !fn(value, valueID) {
+			return
+		}
+	}
+}
+
+// ValueCount implements Backend.
+func (mb *MemoryBackend[K, V]) ValueCount() int {
+	return len(mb.valueToID)
+}
@@ -0,0 +1,14 @@
+package roarindex_test
+
+import (
+	"testing"
+
+	"github.com/thisisdevelopment/roarindex"
+	"github.com/thisisdevelopment/roarindex/backendtest"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	backendtest.Run(t, func() roarindex.Backend[string, string] {
+		return roarindex.NewMemoryBackend[string, string]()
+	})
+}
@@ -0,0 +1,260 @@
+// Package backendtest provides a conformance test suite for implementations
+// of roarindex.Backend.
+package backendtest
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	roaring "github.com/RoaringBitmap/roaring"
+	"github.com/thisisdevelopment/roarindex"
+)
+
+// Run tests a Backend implementation. newBackend must return a new, empty
+// backend on every call.
+func Run(t *testing.T, newBackend func() roarindex.Backend[string, string]) {
+	t.Run("Empty", func(t *testing.T) { testEmpty(t, newBackend()) })
+	t.Run("Keys", func(t *testing.T) { testKeys(t, newBackend()) })
+	t.Run("Values", func(t *testing.T) { testValues(t, newBackend()) })
+	t.Run("Bitmaps", func(t *testing.T) { testBitmaps(t, newBackend()) })
+	t.Run("RangeStops", func(t *testing.T) { testRangeStops(t, newBackend()) })
+	t.Run("ConcurrentReads", func(t *testing.T) { testConcurrentReads(t, newBackend()) })
+	t.Run("RoarIndex", func(t *testing.T) { testRoarIndex(t, newBackend()) })
+	t.Run("Reopen", func(t *testing.T) { testReopen(t, newBackend()) })
+}
+
+func testEmpty(t *testing.T, b roarindex.Backend[string, string]) {
+	if n := b.KeyCount(); n != 0 {
+		t.Errorf("Expected 0 keys, got %d", n)
+	}
+	if n := b.ValueCount(); n != 0 {
+		t.Errorf("Expected 0 values, got %d", n)
+	}
+	if _, exists := b.KeyID("key1"); exists {
+		t.Errorf("Expected key1 not to exist")
+	}
+	if _, exists := b.Key(0); exists {
+		t.Errorf("Expected key ID 0 not to exist")
+	}
+	if _, exists := b.ValueID("value1"); exists {
+		t.Errorf("Expected value1 not to exist")
+	}
+	if _, exists := b.Value(0); exists {
+		t.Errorf("Expected value ID 0 not to exist")
+	}
+	if _, exists := b.Bitmap(0); exists {
+		t.Errorf("Expected bitmap 0 not to exist")
+	}
+	b.RangeKeys(func(key string, _ uint32) bool {
+		t.Errorf("Expected no keys, got %q", key)
+		return true
+	})
+	b.RangeValues(func(value string, _ uint32) bool {
+		t.Errorf("Expected no values, got %q", value)
+		return true
+	})
+	b.RangeBitmaps(func(keyID uint32, _ *roaring.Bitmap) bool {
+		t.Errorf("Expected no bitmaps, got key ID %d", keyID)
+		return true
+	})
+}
+
+func testKeys(t *testing.T, b roarindex.Backend[string, string]) {
+	b.PutKey("key1", 0)
+	b.PutKey("key2", 1)
+	b.PutKey("key3", 7)
+
+	if keyID, exists := b.KeyID("key3"); !exists || keyID != 7 {
+		t.Errorf("Expected key3 to have ID 7, got %d %t", keyID, exists)
+	}
+	if key, exists := b.Key(1); !exists || key != "key2" {
+		t.Errorf("Expected ID 1 to be key2, got %q %t", key, exists)
+	}
+	if n := b.KeyCount(); n != 3 {
+		t.Errorf("Expected 3 keys, got %d", n)
+	}
+
+	b.DeleteKey("key2", 1)
+	if _, exists := b.KeyID("key2"); exists {
+		t.Errorf("Expected key2 to be deleted")
+	}
+	if _, exists := b.Key(1); exists {
+		t.Errorf("Expected key ID 1 to be deleted")
+	}
+
+	got := make(map[string]uint32)
+	b.RangeKeys(func(key string, keyID uint32) bool {
+		got[key] = keyID
+		return true
+	})
+	if len(got) != 2 || got["key1"] != 0 || got["key3"] != 7 {
+		t.Errorf("Expected keys key1 and key3, got %v", got)
+	}
+	if n := b.KeyCount(); n != 2 {
+		t.Errorf("Expected 2 keys, got %d", n)
+	}
+}
+
+func testValues(t *testing.T, b roarindex.Backend[string, string]) {
+	b.PutValue("value1", 0)
+	b.PutValue("", 1)
+	b.PutValue("value3", 2)
+
+	if valueID, exists := b.ValueID(""); !exists || valueID != 1 {
+		t.Errorf("Expected empty value to have ID 1, got %d %t", valueID, exists)
+	}
+	if value, exists := b.Value(2); !exists || value != "value3" {
+		t.Errorf("Expected ID 2 to be value3, got %q %t", value, exists)
+	}
+	if n := b.ValueCount(); n != 3 {
+		t.Errorf("Expected 3 values, got %d", n)
+	}
+
+	got := make(map[string]uint32)
+	b.RangeValues(func(value string, valueID uint32) bool {
+		got[value] = valueID
+		return true
+	})
+	if len(got) != 3 || got["value1"] != 0 || got[""] != 1 || got["value3"] != 2 {
+		t.Errorf("Expected all values, got %v", got)
+	}
+}
+
+func testBitmaps(t *testing.T, b roarindex.Backend[string, string]) {
+	b.PutBitmap(0, roaring.BitmapOf(1, 2, 3))
+	b.PutBitmap(1, roaring.BitmapOf(100000))
+
+	bm, exists := b.Bitmap(0)
+	if !exists || !bm.Equals(roaring.BitmapOf(1, 2, 3)) {
+		t.Errorf("Expected bitmap 0 to be {1,2,3}, got %v %t", bm, exists)
+	}
+
+	// A modified bitmap is stored back with PutBitmap
+	bm.Add(4)
+	b.PutBitmap(0, bm)
+	if bm, _ := b.Bitmap(0); !bm.Equals(roaring.BitmapOf(1, 2, 3, 4)) {
+		t.Errorf("Expected bitmap 0 to be {1,2,3,4}, got %v", bm)
+	}
+
+	// PutBitmap replaces the previous bitmap
+	b.PutBitmap(1, roaring.BitmapOf(5))
+	if bm, _ := b.Bitmap(1); !bm.Equals(roaring.BitmapOf(5)) {
+		t.Errorf("Expected bitmap 1 to be {5}, got %v", bm)
+	}
+
+	got := make(map[uint32]uint64)
+	b.RangeBitmaps(func(keyID uint32, bm *roaring.Bitmap) bool {
+		got[keyID] = bm.GetCardinality()
+		return true
+	})
+	if len(got) != 2 || got[0] != 4 || got[1] != 1 {
+		t.Errorf("Expected bitmaps 0 and 1, got %v", got)
+	}
+
+	b.DeleteBitmap(0)
+	if _, exists := b.Bitmap(0); exists {
+		t.Errorf("Expected bitmap 0 to be deleted")
+	}
+	b.DeleteBitmap(42)
+}
+
+func testRangeStops(t *testing.T, b roarindex.Backend[string, string]) {
+	for i, s := range []string{"a", "b", "c"} {
+		b.PutKey(s, uint32(i))
+		b.PutValue(s, uint32(i))
+		b.PutBitmap(uint32(i), roaring.BitmapOf(uint32(i)))
+	}
+
+	calls := 0
+	b.RangeKeys(func(string, uint32) bool { calls++; return false })
+	b.RangeValues(func(string, uint32) bool { calls++; return false })
+	b.RangeBitmaps(func(uint32, *roaring.Bitmap) bool { calls++; return false })
+	if calls != 3 {
+		t.Errorf("Expected ranges to stop after one call each, got %d calls", calls)
+	}
+}
+
+func testConcurrentReads(t *testing.T, b roarindex.Backend[string, string]) {
+	for i := uint32(0); i < 100; i++ {
+		s := string(rune('a' + i))
+		b.PutKey(s, i)
+		b.PutValue(s, i)
+		b.PutBitmap(i, roaring.BitmapOf(i, i+1))
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := uint32(0); i < 100; i++ {
+				s := string(rune('a' + i))
+				if keyID, _ := b.KeyID(s); keyID != i {
+					t.Errorf("Expected key %q to have ID %d, got %d", s, i, keyID)
+				}
+				if value, _ := b.Value(i); value != s {
+					t.Errorf("Expected value ID %d to be %q, got %q", i, s, value)
+				}
+				if bm, _ := b.Bitmap(i); bm == nil || !bm.Contains(i) {
+					t.Errorf("Expected bitmap %d to contain %d", i, i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func testRoarIndex(t *testing.T, b roarindex.Backend[string, string]) {
+	om := roarindex.NewRoarIndexWithBackend(b)
+	om.PushMap("key1", "value1")
+	om.PushMap("key1", "value2")
+	om.PushMap("key2", "value2")
+	om.PushMap("key3", "value3")
+	om.DeleteMap("key3")
+
+	values, err := om.GetMap("key1")
+	if err != nil {
+		t.Errorf("Expected key1 to exist, got error: %v", err)
+	}
+	slices.Sort(values)
+	if !slices.Equal(values, []string{"value1", "value2"}) {
+		t.Errorf("Expected [value1 value2], got %v", values)
+	}
+	if !om.HasValue("key2", "value2") || om.HasValue("key2", "value1") {
+		t.Errorf("HasValue returned wrong results for key2")
+	}
+	if _, err := om.GetMap("key3"); err != roarindex.ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound for deleted key3, got %v", err)
+	}
+	if n := om.Count(); n != 2 {
+		t.Errorf("Expected 2 keys, got %d", n)
+	}
+	keys := om.Keys()
+	slices.Sort(keys)
+	if !slices.Equal(keys, []string{"key1", "key2"}) {
+		t.Errorf("Expected [key1 key2], got %v", keys)
+	}
+	if n := len(om.Values()); n != 3 {
+		t.Errorf("Expected 3 values, got %d", n)
+	}
+}
+
+func testReopen(t *testing.T, b roarindex.Backend[string, string]) {
+	om := roarindex.NewRoarIndexWithBackend(b)
+	om.PushMap("key1", "value1")
+	om.PushMap("key2", "value2")
+
+	// A new RoarIndex over the same backend sees the data and does not reuse IDs
+	om = roarindex.NewRoarIndexWithBackend(b)
+	om.PushMap("key3", "value3")
+	if !om.HasValue("key1", "value1") || !om.HasValue("key3", "value3") {
+		t.Errorf("Expected reopened index to hold old and new values")
+	}
+	if om.HasValue("key3", "value1") || om.HasValue("key3", "value2") {
+		t.Errorf("Expected new value not to collide with existing IDs")
+	}
+	if n := om.Count(); n != 3 {
+		t.Errorf("Expected 3 keys, got %d", n)
+	}
+}
@@ -0,0 +1,122 @@
+package roarindex
+
+import (
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// GetMaps retrieves the values of several keys under a single lock
+// acquisition, as GetMap would for each key, and lists the keys that are
+// not in the RoarIndex in missing. The value slices share one buffer and
+// must not be appended to in place.
+func (om *RoarIndex[K, V]) GetMaps(keys []K) (result map[K][]V, missing []K) {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	// Look up all bitmaps first to size the shared buffer, a nil bitmap
+	// marks a missing key
+	empty := roaring.NewBitmap()
+	bitmaps := make([]*roaring.Bitmap, len(keys))
+	total := uint64(0)
+	for i, key := range keys {
+		keyID, keyExists := om.backend.KeyID(key)
+		if !keyExists {
+			continue
+		}
+		bm, exists := om.backend.Bitmap(keyID)
+		if !exists {
+			bm = empty
+		}
+		bitmaps[i] = bm
+		total += bm.GetCardinality()
+	}
+
+	result = make(map[K][]V, len(keys))
+	buf := make([]V, 0, total)
+	// Reuse a single iterator and ID buffer for all keys
+	var it roaring.ManyIntIterator
+	ids := make([]uint32, 256)
+	// Missing keys listed so far, so repeated keys are listed once
+	var listed map[K]struct{}
+	for i, key := range keys {
+		switch bm := bitmaps[i]; bm {
+		case nil:
+			if _, seen := listed[key]; !seen {
+				if listed == nil {
+					listed = make(map[K]struct{})
+				}
+				listed[key] = struct{}{}
+				missing = append(missing, key)
+			}
+		case empty:
+			result[key] = nil
+		default:
+			start := len(buf)
+			it.Initialize(bm)
+			for n := it.NextMany(ids); n > 0; n = it.NextMany(ids) {
+				for _, valueID := range ids[:n] {
+					if value, valueExists := om.backend.Value(valueID); valueExists {
+						buf = append(buf, value)
+					}
+				}
+			}
+			om.sortValues(buf[start:])
+			// Cap each slice so appending to it cannot overwrite the next key
+			result[key] = buf[start:len(buf):len(buf)]
+		}
+	}
+	return result, missing
+}
+
+// HasValues checks for each of the values whether it is associated with
+// key, under a single lock acquisition.
+func (om *RoarIndex[K, V]) HasValues(key K, values []V) []bool {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	has := make([]bool, len(values))
+	keyID, keyExists := om.backend.KeyID(key)
+	if !keyExists {
+		return has
+	}
+	bm, exists := om.backend.Bitmap(keyID)
+	if !exists {
+		return has
+	}
+
+	for i, value := range values {
+		if valueID, valueExists := om.backend.ValueID(value); valueExists {
+			has[i] = bm.Contains(valueID)
+		}
+	}
+	return has
+}
+
+// PushValues associates the values with key under a single lock
+// acquisition and returns how many of them were not associated with it yet.
+func (om *RoarIndex[K, V]) PushValues(key K, values ...V) int {
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	added := 0
+	for _, value := range values {
+		if om.pushLocked(key, value) {
+			added++
+		}
+	}
+	return added
+}
+
+// DeleteMaps removes the keys and their values under a single lock
+// acquisition and returns how many of them were in the RoarIndex.
+func (om *RoarIndex[K, V]) DeleteMaps(keys ...K) int {
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	deleted := 0
+	for _, key := range keys {
+		if om.deleteLocked(key) {
+			deleted++
+		}
+	}
+	return deleted
+}
@@ -0,0 +1,73 @@
+package roarindex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoarIndexGetMaps(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	for _, v := range []int{1, 2, 3} {
+		om.PushMap("map1", v)
+	}
+	om.PushMap("map2", 4)
+
+	result, missing := om.GetMaps([]string{"map1", "nonExistent", "map2", "map1", "nonExistent"})
+	if !reflect.DeepEqual(missing, []string{"nonExistent"}) {
+		t.Errorf("Expected [nonExistent] to be missing, but got %v", missing)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 keys, but got %v", result)
+	}
+	if !reflect.DeepEqual(result["map1"], []int{1, 2, 3}) || !reflect.DeepEqual(result["map2"], []int{4}) {
+		t.Errorf("Unexpected result %v", result)
+	}
+
+	// Appending to one result must not change another
+	_ = append(result["map1"], 42)
+	if !reflect.DeepEqual(result["map2"], []int{4}) {
+		t.Errorf("Expected map2 to be unchanged after appending to map1, but got %v", result["map2"])
+	}
+
+	result, missing = om.GetMaps(nil)
+	if len(result) != 0 || len(missing) != 0 {
+		t.Errorf("Expected empty results for no keys, but got %v and %v", result, missing)
+	}
+}
+
+func TestRoarIndexHasValues(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	om.PushMap("map1", 1)
+	om.PushMap("map1", 3)
+	om.PushMap("map2", 2)
+
+	if has := om.HasValues("map1", []int{1, 2, 3, 4}); !reflect.DeepEqual(has, []bool{true, false, true, false}) {
+		t.Errorf("Expected [true false true false], but got %v", has)
+	}
+	if has := om.HasValues("nonExistent", []int{1, 2}); !reflect.DeepEqual(has, []bool{false, false}) {
+		t.Errorf("Expected [false false] for a missing key, but got %v", has)
+	}
+}
+
+func TestRoarIndexPushValuesDeleteMaps(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	om.PushMap("map1", 1)
+
+	if added := om.PushValues("map1", 1, 2, 3, 2); added != 2 {
+		t.Errorf("Expected 2 values added, but got %d", added)
+	}
+	if n := om.Cardinality("map1"); n != 3 {
+		t.Errorf("Expected cardinality 3, but got %d", n)
+	}
+	if n := om.Cardinality("nonExistent"); n != 0 {
+		t.Errorf("Expected cardinality 0 for a missing key, but got %d", n)
+	}
+
+	om.PushMap("map2", 4)
+	if deleted := om.DeleteMaps("map1", "nonExistent", "map2", "map1"); deleted != 2 {
+		t.Errorf("Expected 2 keys deleted, but got %d", deleted)
+	}
+	if om.Count() != 0 {
+		t.Errorf("Expected no keys left, but got %d", om.Count())
+	}
+}
@@ -0,0 +1,348 @@
+package roarindex
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// Builder bulk loads a RoarIndex from many goroutines. Each goroutine adds
+// pairs to its own BuilderWorker without any locking; Build then assigns
+// the IDs and builds the bitmaps in parallel.
+type Builder[K comparable, V comparable] struct {
+	mtx     sync.Mutex
+	workers []*BuilderWorker[K, V]
+}
+
+// BuilderWorker buffers the pairs added by a single goroutine. It is not
+// safe for concurrent use.
+type BuilderWorker[K comparable, V comparable] struct {
+	// Local dictionaries, local IDs index keys and values
+	keyIDs   map[K]uint32
+	keys     []K
+	valueIDs map[V]uint32
+	values   []V
+
+	// Local key and value ID of every pair added
+	pairKeys   []uint32
+	pairValues []uint32
+}
+
+// NewBuilder creates a new Builder.
+func NewBuilder[K comparable, V comparable]() *Builder[K, V] {
+	return &Builder[K, V]{}
+}
+
+// Worker returns a new BuilderWorker for a goroutine to add pairs to.
+func (b *Builder[K, V]) Worker() *BuilderWorker[K, V] {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	w := &BuilderWorker[K, V]{
+		keyIDs:   make(map[K]uint32),
+		valueIDs: make(map[V]uint32),
+	}
+	b.workers = append(b.workers, w)
+	return w
+}
+
+// Add associates a value with a key.
+func (w *BuilderWorker[K, V]) Add(key K, value V) {
+	keyID, exists := w.keyIDs[key]
+	if !exists {
+		keyID = uint32(len(w.keys))
+		w.keyIDs[key] = keyID
+		w.keys = append(w.keys, key)
+	}
+
+	valueID, exists := w.valueIDs[value]
+	if !exists {
+		valueID = uint32(len(w.values))
+		w.valueIDs[value] = valueID
+		w.values = append(w.values, value)
+	}
+
+	w.pairKeys = append(w.pairKeys, keyID)
+	w.pairValues = append(w.pairValues, valueID)
+}
+
+// Build returns a RoarIndex holding the pairs added to all workers, which
+// must be done adding. Keys and values get their IDs in the order the
+// workers were created and then in the order each worker saw them first,
+// so the same input split the same way always yields the same IDs. The
+// workers are emptied and must not be used afterwards.
+func (b *Builder[K, V]) Build() *RoarIndex[K, V] {
+	return b.BuildWithBackend(NewMemoryBackend[K, V]())
+}
+
+// BuildWithBackend is Build storing the RoarIndex in backend, which should
+// be empty. An empty MemoryBackend is filled in parallel, other backends on
+// a single goroutine as Backend does not allow concurrent writes.
+func (b *Builder[K, V]) BuildWithBackend(backend Backend[K, V]) *RoarIndex[K, V] {
+	b.mtx.Lock()
+	workers := b.workers
+	b.workers = nil
+	b.mtx.Unlock()
+
+	// Assign global IDs to the keys and values of all workers, both
+	// dictionaries at once
+	localKeys := make([][]K, len(workers))
+	localValues := make([][]V, len(workers))
+	for i, w := range workers {
+		localKeys[i], localValues[i] = w.keys, w.values
+	}
+	var keys []K
+	var values []V
+	var keyMaps, valueMaps [][]uint32
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		keys, keyMaps = assignIDs(localKeys)
+	}()
+	go func() {
+		defer wg.Done()
+		values, valueMaps = assignIDs(localValues)
+	}()
+	wg.Wait()
+
+	// Split the key IDs into one range per CPU, and sort the pairs of each
+	// worker into buckets per range in parallel
+	ranges := max(1, min(runtime.GOMAXPROCS(0), len(keys)))
+	rangeSize := max(1, (len(keys)+ranges-1)/ranges)
+	buckets := make([][][]uint64, len(workers))
+
+	for i, w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			buckets[i] = make([][]uint64, ranges)
+			for j, localKeyID := range w.pairKeys {
+				keyID := keyMaps[i][localKeyID]
+				valueID := valueMaps[i][w.pairValues[j]]
+				r := int(keyID) / rangeSize
+				buckets[i][r] = append(buckets[i][r], uint64(keyID)<<32|uint64(valueID))
+			}
+			*w = BuilderWorker[K, V]{}
+		}()
+	}
+	wg.Wait()
+
+	// Build the bitmaps of each key range in parallel
+	bitmaps := make([]*roaring.Bitmap, len(keys))
+	for r := 0; r < ranges; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range buckets {
+				for _, pair := range buckets[i][r] {
+					keyID, valueID := uint32(pair>>32), uint32(pair)
+					if bitmaps[keyID] == nil {
+						bitmaps[keyID] = roaring.NewBitmap()
+					}
+					bitmaps[keyID].Add(valueID)
+				}
+				buckets[i][r] = nil
+			}
+		}()
+	}
+	wg.Wait()
+
+	if mb, ok := backend.(*MemoryBackend[K, V]); ok && mb.empty() {
+		fillMemoryBackend(mb, keys, values, bitmaps)
+	} else {
+		// Backends don't allow concurrent writes
+		for valueID, value := range values {
+			backend.PutValue(value, uint32(valueID))
+		}
+		for keyID, key := range keys {
+			backend.PutKey(key, uint32(keyID))
+			backend.PutBitmap(uint32(keyID), bitmaps[keyID])
+		}
+	}
+	return NewRoarIndexWithBackend(backend)
+}
+
+// builderChunkSize is the number of dictionary entries a goroutine handles
+// at a time while assigning IDs.
+const builderChunkSize = 16 * 1024
+
+// builderChunk is a range of the local dictionary of a worker.
+type builderChunk struct {
+	worker, lo, hi int
+}
+
+// assignIDs gives the entries of the local dictionaries of all workers
+// global IDs, in the order of their first occurrence by worker and then by
+// local ID, and returns the entries by global ID and per worker the global
+// ID of each local ID. The result is the same as assigning the IDs one by
+// one, but the work is spread over all CPUs.
+func assignIDs[T comparable](locals [][]T) ([]T, [][]uint32) {
+	var chunks []builderChunk
+	for i, local := range locals {
+		for lo := 0; lo < len(local); lo += builderChunkSize {
+			chunks = append(chunks, builderChunk{i, lo, min(lo+builderChunkSize, len(local))})
+		}
+	}
+
+	if len(chunks) <= 1 || runtime.GOMAXPROCS(0) == 1 {
+		return assignIDsSerial(locals)
+	}
+
+	// Find the first occurrence of every entry, ranked by worker and then
+	// by local ID
+	rank := func(worker, localID int) uint64 {
+		return uint64(worker)<<32 | uint64(localID)
+	}
+	var first sync.Map
+	ranks := make([][]uint64, len(locals))
+	for i, local := range locals {
+		ranks[i] = make([]uint64, len(local))
+	}
+	forEachChunk(chunks, func(j int, c builderChunk) {
+		for localID, entry := range locals[c.worker][c.lo:c.hi] {
+			r := rank(c.worker, c.lo+localID)
+			actual, loaded := first.LoadOrStore(entry, r)
+			for loaded && r < actual.(uint64) {
+				if first.CompareAndSwap(entry, actual, r) {
+					break
+				}
+				actual, _ = first.Load(entry)
+			}
+		}
+	})
+
+	// Count the first occurrences per chunk, whose running total is where
+	// the IDs of the next chunk start
+	owned := make([]int, len(chunks))
+	forEachChunk(chunks, func(j int, c builderChunk) {
+		n := 0
+		for localID, entry := range locals[c.worker][c.lo:c.hi] {
+			r, _ := first.Load(entry)
+			ranks[c.worker][c.lo+localID] = r.(uint64)
+			if r.(uint64) == rank(c.worker, c.lo+localID) {
+				n++
+			}
+		}
+		owned[j] = n
+	})
+	starts := make([]uint32, len(chunks))
+	total := uint32(0)
+	for j, n := range owned {
+		starts[j] = total
+		total += uint32(n)
+	}
+
+	// Number the first occurrences, then translate the other occurrences
+	// to the IDs of their first occurrence
+	global := make([]T, total)
+	maps := make([][]uint32, len(locals))
+	for i, local := range locals {
+		maps[i] = make([]uint32, len(local))
+	}
+	forEachChunk(chunks, func(j int, c builderChunk) {
+		id := starts[j]
+		for localID, entry := range locals[c.worker][c.lo:c.hi] {
+			if ranks[c.worker][c.lo+localID] == rank(c.worker, c.lo+localID) {
+				global[id] = entry
+				maps[c.worker][c.lo+localID] = id
+				id++
+			}
+		}
+	})
+	forEachChunk(chunks, func(j int, c builderChunk) {
+		for localID := c.lo; localID < c.hi; localID++ {
+			if r := ranks[c.worker][localID]; r != rank(c.worker, localID) {
+				maps[c.worker][localID] = maps[r>>32][uint32(r)]
+			}
+		}
+	})
+	return global, maps
+}
+
+// assignIDsSerial is assignIDs on a single goroutine, which saves the
+// synchronization when there is nothing to run in parallel.
+func assignIDsSerial[T comparable](locals [][]T) ([]T, [][]uint32) {
+	ids := make(map[T]uint32)
+	var global []T
+	maps := make([][]uint32, len(locals))
+	for i, local := range locals {
+		maps[i] = make([]uint32, len(local))
+		for localID, entry := range local {
+			id, exists := ids[entry]
+			if !exists {
+				id = uint32(len(global))
+				ids[entry] = id
+				global = append(global, entry)
+			}
+			maps[i][localID] = id
+		}
+	}
+	return global, maps
+}
+
+// forEachChunk calls fn for every chunk and its index, on up to GOMAXPROCS
+// goroutines.
+func forEachChunk(chunks []builderChunk, fn func(j int, c builderChunk)) {
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), len(chunks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := int(next.Add(1)) - 1; j < len(chunks); j = int(next.Add(1)) - 1 {
+				fn(j, chunks[j])
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// fillMemoryBackend fills the maps of an empty MemoryBackend, each on its
+// own goroutine and sized up front.
+func fillMemoryBackend[K comparable, V comparable](mb *MemoryBackend[K, V], keys []K, values []V, bitmaps []*roaring.Bitmap) {
+	var wg sync.WaitGroup
+	fill := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
+
+	fill(func() {
+		mb.keyToID = make(map[K]uint32, len(keys))
+		for keyID, key := range keys {
+			mb.keyToID[key] = uint32(keyID)
+		}
+	})
+	fill(func() {
+		mb.idToKey = make(map[uint32]K, len(keys))
+		for keyID, key := range keys {
+			mb.idToKey[uint32(keyID)] = key
+		}
+	})
+	fill(func() {
+		mb.data = make(map[uint32]*roaring.Bitmap, len(bitmaps))
+		for keyID, bm := range bitmaps {
+			mb.data[uint32(keyID)] = bm
+		}
+	})
+	fill(func() {
+		mb.valueToID = make(map[V]uint32, len(values))
+		for valueID, value := range values {
+			mb.valueToID[value] = uint32(valueID)
+		}
+	})
+	fill(func() {
+		mb.idToValue = make(map[uint32]V, len(values))
+		for valueID, value := range values {
+			mb.idToValue[uint32(valueID)] = value
+		}
+	})
+	wg.Wait()
+}
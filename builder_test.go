@@ -0,0 +1,123 @@
+package roarindex
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// buildConcurrently adds pairs i (key i%97, value i%1013) for i below n,
+// split over the given number of workers.
+func buildConcurrently(n, workers int) *RoarIndex[int, int] {
+	builder := NewBuilder[int, int]()
+	per := (n + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		worker := builder.Worker()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := w * per; i < min(n, (w+1)*per); i++ {
+				worker.Add(i%97, i%1013)
+			}
+		}()
+	}
+	wg.Wait()
+	return builder.Build()
+}
+
+func TestBuilder(t *testing.T) {
+	const n = 100_000
+	expected := NewRoarIndex[int, int]()
+	for i := 0; i < n; i++ {
+		expected.PushMap(i%97, i%1013)
+	}
+
+	for _, workers := range []int{1, 3, 8} {
+		om := buildConcurrently(n, workers)
+		if result := Diff(expected, om); !result.Equal() {
+			t.Errorf("Expected the built index with %d workers to equal the pushed one, but got %+v", workers, result)
+		}
+
+		// The index must keep working after the build
+		om.PushMap(1000, 1)
+		om.PushMap(1, 5000)
+		if !om.HasValue(1000, 1) || !om.HasValue(1, 5000) || !om.HasValue(1, 1) {
+			t.Errorf("Expected pushes after Build to work")
+		}
+	}
+}
+
+func TestBuilderDeterministicIDs(t *testing.T) {
+	a := buildConcurrently(10_000, 4)
+	b := buildConcurrently(10_000, 4)
+
+	a.backend.RangeKeys(func(key int, keyID uint32) bool {
+		if otherID, _ := b.backend.KeyID(key); otherID != keyID {
+			t.Errorf("Expected key %d to get ID %d in both builds, but got %d", key, keyID, otherID)
+		}
+		return true
+	})
+	a.backend.RangeValues(func(value int, valueID uint32) bool {
+		if otherID, _ := b.backend.ValueID(value); otherID != valueID {
+			t.Errorf("Expected value %d to get ID %d in both builds, but got %d", value, valueID, otherID)
+		}
+		return true
+	})
+}
+
+func TestBuilderEmpty(t *testing.T) {
+	builder := NewBuilder[string, string]()
+	builder.Worker()
+	om := builder.Build()
+	if om.Count() != 0 {
+		t.Errorf("Expected an empty index, but got %d keys", om.Count())
+	}
+	om.PushMap("key1", "value1")
+	if !om.HasValue("key1", "value1") {
+		t.Errorf("Expected key1 to hold value1")
+	}
+}
+
+func TestAssignIDs(t *testing.T) {
+	// Local dictionaries spanning several chunks, sharing entries
+	locals := make([][]int, 3)
+	for i := range locals {
+		for j := 0; j < 2*builderChunkSize+10; j++ {
+			locals[i] = append(locals[i], (i+1)*j%(3*builderChunkSize))
+		}
+	}
+
+	// Reference: assign the IDs one by one
+	ids := make(map[int]uint32)
+	var expected []int
+	for _, local := range locals {
+		for _, entry := range local {
+			if _, exists := ids[entry]; !exists {
+				ids[entry] = uint32(len(expected))
+				expected = append(expected, entry)
+			}
+		}
+	}
+
+	// Run in parallel even on a single CPU
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	for name, assign := range map[string]func([][]int) ([]int, [][]uint32){
+		"Parallel": assignIDs[int],
+		"Serial":   assignIDsSerial[int],
+	} {
+		global, maps := assign(locals)
+		if !reflect.DeepEqual(global, expected) {
+			t.Fatalf("%s: expected %d entries in first occurrence order, but got %d", name, len(expected), len(global))
+		}
+		for i, local := range locals {
+			for localID, entry := range local {
+				if maps[i][localID] != ids[entry] {
+					t.Fatalf("%s: expected entry %d of worker %d to get ID %d, but got %d", name, entry, i, ids[entry], maps[i][localID])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/thisisdevelopment/roarindex"
+)
+
+type index = roarindex.RoarIndex[string, string]
+
+func cmdStats(args []string, stdout io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: stats <file>", errUsage)
+	}
+	om, err := readIndex(args[0])
+	if err != nil {
+		return err
+	}
+
+	stats := om.Stats()
+	fmt.Fprintf(stdout, "keys:         %d\n", stats.Keys)
+	fmt.Fprintf(stdout, "values:       %d\n", stats.Values)
+	fmt.Fprintf(stdout, "pairs:        %d\n", stats.Pairs)
+	fmt.Fprintf(stdout, "bitmap bytes: %d\n", stats.BitmapBytes)
+	return nil
+}
+
+func cmdGet(args []string, stdout io.Writer) error {
+	if len(args) != 2 {
+		return fmt.Errorf("%w: get <file> <key>", errUsage)
+	}
+	om, err := readIndex(args[0])
+	if err != nil {
+		return err
+	}
+
+	values, err := om.GetMap(args[1])
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[1], err)
+	}
+	return writeLines(stdout, values)
+}
+
+func cmdHas(args []string, stdout io.Writer) error {
+	if len(args) != 3 {
+		return fmt.Errorf("%w: has <file> <key> <value>", errUsage)
+	}
+	om, err := readIndex(args[0])
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(stdout, om.HasValue(args[1], args[2]))
+	return err
+}
+
+func cmdKeys(args []string, stdout io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: keys <file>", errUsage)
+	}
+	om, err := readIndex(args[0])
+	if err != nil {
+		return err
+	}
+	return writeLines(stdout, om.Keys())
+}
+
+func cmdValues(args []string, stdout io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: values <file>", errUsage)
+	}
+	om, err := readIndex(args[0])
+	if err != nil {
+		return err
+	}
+	return writeLines(stdout, om.Values())
+}
+
+func cmdDump(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("dump", flag.ContinueOnError)
+	format := fs.String("format", "json", "output format, json, jsonl, csv or columnar")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		return fmt.Errorf("%w: dump [--format json|jsonl|csv|columnar] <file>", errUsage)
+	}
+	om, err := readIndex(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "json":
+		return om.ExportJSON(stdout)
+	case "jsonl":
+		return om.ExportJSONL(stdout)
+	case "csv":
+		return om.ExportCSV(stdout, nil)
+	case "columnar":
+		return om.ExportColumnar(stdout)
+	default:
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+}
+
+func cmdLoad(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("load", flag.ContinueOnError)
+	format := fs.String("format", "", "input format, csv or jsonl, guessed from the input extension when empty")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 2 {
+		return fmt.Errorf("%w: load [--format csv|jsonl] <file> <input>", errUsage)
+	}
+	path, input := fs.Arg(0), fs.Arg(1)
+
+	if *format == "" {
+		*format = "csv"
+		if ext := strings.ToLower(filepath.Ext(input)); ext == ".jsonl" || ext == ".ndjson" {
+			*format = "jsonl"
+		}
+	}
+
+	om, err := readIndex(path)
+	if errors.Is(err, os.ErrNotExist) {
+		om, err = roarindex.NewRoarIndex[string, string](), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(input)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var loaded int
+	switch *format {
+	case "csv":
+		loaded, err = om.ImportCSV(f, nil)
+	case "jsonl":
+		loaded, err = om.ImportJSONL(f, nil)
+	default:
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", input, err)
+	}
+
+	if err := writeIndex(path, om); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "loaded %d pairs\n", loaded)
+	return err
+}
+
+func cmdDiff(args []string, stdout io.Writer) error {
+	if len(args) != 2 {
+		return fmt.Errorf("%w: diff <a> <b>", errUsage)
+	}
+	a, err := readIndex(args[0])
+	if err != nil {
+		return err
+	}
+	b, err := readIndex(args[1])
+	if err != nil {
+		return err
+	}
+
+	var entries []roarindex.DiffEntry[string, string]
+	roarindex.DiffFunc(a, b, func(entry roarindex.DiffEntry[string, string]) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	slices.SortFunc(entries, func(x, y roarindex.DiffEntry[string, string]) int {
+		return strings.Compare(x.Key, y.Key)
+	})
+
+	bw := bufio.NewWriter(stdout)
+	for _, entry := range entries {
+		switch entry.Kind {
+		case roarindex.DiffOnlyInA:
+			fmt.Fprintf(bw, "- %s\n", entry.Key)
+		case roarindex.DiffOnlyInB:
+			fmt.Fprintf(bw, "+ %s\n", entry.Key)
+		case roarindex.DiffChanged:
+			slices.Sort(entry.Removed)
+			for _, value := range entry.Removed {
+				fmt.Fprintf(bw, "~ %s -%s\n", entry.Key, value)
+			}
+			slices.Sort(entry.Added)
+			for _, value := range entry.Added {
+				fmt.Fprintf(bw, "~ %s +%s\n", entry.Key, value)
+			}
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return errDiffers
+	}
+	return nil
+}
+
+func cmdCompact(args []string, stdout io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: compact <file>", errUsage)
+	}
+	om, err := readIndex(args[0])
+	if err != nil {
+		return err
+	}
+	before := om.Stats()
+
+	// Merging into an empty index renumbers the IDs densely and drops values
+	// no key refers to, while keeping keys that have no values
+	compacted := roarindex.NewRoarIndex[string, string]()
+	if err := compacted.Merge(om, roarindex.MergeUnion); err != nil {
+		return err
+	}
+	compacted.Optimize()
+	after := compacted.Stats()
+
+	if err := writeIndex(args[0], compacted); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "values: %d -> %d\nbitmap bytes: %d -> %d\n",
+		before.Values, after.Values, before.BitmapBytes, after.BitmapBytes)
+	return err
+}
+
+// readIndex reads a snapshot file.
+func readIndex(path string) (*index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	om, err := roarindex.ReadRoarIndex[string, string](f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return om, nil
+}
+
+// writeIndex replaces a snapshot file, writing to a temporary file first
+// so a failed write leaves the old file intact.
+func writeIndex(path string, om *index) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	// Keep the permissions of the file being replaced
+	if info, err := os.Stat(path); err == nil {
+		if err := f.Chmod(info.Mode().Perm()); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if _, err := om.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// writeLines writes items sorted, one per line.
+func writeLines(stdout io.Writer, items []string) error {
+	slices.Sort(items)
+
+	bw := bufio.NewWriter(stdout)
+	for _, item := range items {
+		fmt.Fprintln(bw, item)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,70 @@
+// Command roarindex inspects and manipulates RoarIndex[string, string]
+// snapshot files as written by RoarIndex.WriteTo.
+//
+// Usage:
+//
+//	roarindex stats <file>
+//	roarindex get <file> <key>
+//	roarindex has <file> <key> <value>
+//	roarindex keys <file>
+//	roarindex values <file>
+//	roarindex dump [--format json|jsonl|csv|columnar] <file>
+//	roarindex load [--format csv|jsonl] <file> <input>
+//	roarindex diff <a> <b>
+//	roarindex compact <file>
+//
+// The keys and values commands list in sorted order, dump writes the keys
+// in the order they were first pushed. load creates the file when it does
+// not exist and reads key,value rows from CSV or {"key": ..., "value": ...}
+// objects from JSON Lines, guessing the format from the input file
+// extension. diff exits with status 1 when the files differ, any error exits
+// with status 2.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// errDiffers makes the process exit with status 1 without a message.
+var errDiffers = errors.New("indexes differ")
+
+// errUsage is returned for invalid command lines.
+var errUsage = errors.New("usage: roarindex stats|get|has|keys|values|dump|load|diff|compact ...")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, errDiffers) {
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
+
+// run executes the command line args, writing its output to stdout.
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	command, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+	}
+	return command(args[1:], stdout)
+}
+
+var commands = map[string]func(args []string, stdout io.Writer) error{
+	"stats":   cmdStats,
+	"get":     cmdGet,
+	"has":     cmdHas,
+	"keys":    cmdKeys,
+	"values":  cmdValues,
+	"dump":    cmdDump,
+	"load":    cmdLoad,
+	"diff":    cmdDiff,
+	"compact": cmdCompact,
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thisisdevelopment/roarindex"
+)
+
+// runOK runs a command line and returns its output, failing on errors.
+func runOK(t *testing.T, args ...string) string {
+	t.Helper()
+	var stdout bytes.Buffer
+	if err := run(args, &stdout); err != nil {
+		t.Fatalf("%v failed: %v", args, err)
+	}
+	return stdout.String()
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	return path
+}
+
+func TestLoadAndInspect(t *testing.T) {
+	dir := t.TempDir()
+	index := filepath.Join(dir, "index.roar")
+	csvInput := writeFile(t, dir, "pairs.csv", "key1,value1\nkey1,value2\nkey2,value2\n")
+	jsonlInput := writeFile(t, dir, "pairs.jsonl", `{"key": "key3", "value": "value3"}`+"\n\n"+`{"key": "key1", "value": "value3"}`+"\n")
+
+	if out := runOK(t, "load", index, csvInput); out != "loaded 3 pairs\n" {
+		t.Errorf("Unexpected load output %q", out)
+	}
+	runOK(t, "load", index, jsonlInput)
+
+	if out := runOK(t, "get", index, "key1"); out != "value1\nvalue2\nvalue3\n" {
+		t.Errorf("Unexpected get output %q", out)
+	}
+	if out := runOK(t, "has", index, "key2", "value2"); out != "true\n" {
+		t.Errorf("Unexpected has output %q", out)
+	}
+	if out := runOK(t, "has", index, "key2", "value1"); out != "false\n" {
+		t.Errorf("Unexpected has output %q", out)
+	}
+	if out := runOK(t, "keys", index); out != "key1\nkey2\nkey3\n" {
+		t.Errorf("Unexpected keys output %q", out)
+	}
+	if out := runOK(t, "values", index); out != "value1\nvalue2\nvalue3\n" {
+		t.Errorf("Unexpected values output %q", out)
+	}
+	if out := runOK(t, "stats", index); !strings.Contains(out, "keys:         3\n") || !strings.Contains(out, "pairs:        5\n") {
+		t.Errorf("Unexpected stats output %q", out)
+	}
+
+	if out := runOK(t, "dump", "--format", "csv", index); out != "key1,value1\nkey1,value2\nkey1,value3\nkey2,value2\nkey3,value3\n" {
+		t.Errorf("Unexpected csv dump %q", out)
+	}
+	if out := runOK(t, "dump", index); !strings.Contains(out, `{"key":"key3","values":["value3"]}`) {
+		t.Errorf("Unexpected json dump %q", out)
+	}
+
+	var stdout bytes.Buffer
+	if err := run([]string{"get", index, "nonExistent"}, &stdout); err == nil || !strings.Contains(err.Error(), "key not found") {
+		t.Errorf("Expected key not found, got %v", err)
+	}
+}
+
+func TestLoadMalformedInput(t *testing.T) {
+	dir := t.TempDir()
+	index := filepath.Join(dir, "index.roar")
+	input := writeFile(t, dir, "pairs.jsonl", `{"key": "key1", "value": "value1"}`+"\n{oops\n")
+
+	var stdout bytes.Buffer
+	err := run([]string{"load", index, input}, &stdout)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error on line 2, got %v", err)
+	}
+	if _, err := os.Stat(index); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected no index to be written on failure")
+	}
+}
+
+func TestDiffAndCompact(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.roar")
+	b := filepath.Join(dir, "b.roar")
+	runOK(t, "load", a, writeFile(t, dir, "a.csv", "key1,value1\nkey1,value2\nkey2,value1\n"))
+	runOK(t, "load", b, writeFile(t, dir, "b.csv", "key1,value1\nkey1,value3\nkey3,value1\n"))
+
+	if out := runOK(t, "diff", a, a); out != "" {
+		t.Errorf("Expected no differences, got %q", out)
+	}
+
+	var stdout bytes.Buffer
+	err := run([]string{"diff", a, b}, &stdout)
+	if !errors.Is(err, errDiffers) {
+		t.Errorf("Expected errDiffers, got %v", err)
+	}
+	if out := stdout.String(); out != "~ key1 -value2\n~ key1 +value3\n- key2\n+ key3\n" {
+		t.Errorf("Unexpected diff output %q", out)
+	}
+
+	out := runOK(t, "compact", a)
+	if !strings.Contains(out, "values: 2 -> 2") {
+		t.Errorf("Unexpected compact output %q", out)
+	}
+	if out := runOK(t, "diff", a, filepath.Join(dir, "a.roar")); out != "" {
+		t.Errorf("Expected compact to keep the contents, got %q", out)
+	}
+}
+
+func TestCompactKeepsEmptyKeysAndMode(t *testing.T) {
+	// Intersecting leaves key1 without values
+	om := roarindex.NewRoarIndex[string, string]()
+	om.PushMap("key1", "value1")
+	om.PushMap("key2", "value2")
+	other := roarindex.NewRoarIndex[string, string]()
+	other.PushMap("key1", "value3")
+	om.Merge(other, roarindex.MergeIntersect)
+
+	path := filepath.Join(t.TempDir(), "a.roar")
+	var buf bytes.Buffer
+	om.WriteTo(&buf)
+	if err := os.WriteFile(path, buf.Bytes(), 0o640); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if out := runOK(t, "compact", path); !strings.Contains(out, "values: 3 -> 1") {
+		t.Errorf("Unexpected compact output %q", out)
+	}
+	compacted, err := readIndex(path)
+	if err != nil {
+		t.Fatalf("readIndex failed: %v", err)
+	}
+	if keys := compacted.Keys(); len(keys) != 2 {
+		t.Errorf("Expected key1 and key2 after compacting, got %v", keys)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o640 {
+		t.Errorf("Expected mode 0640 after compacting, got %v", mode)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	var stdout bytes.Buffer
+	for _, args := range [][]string{nil, {"nonExistent"}, {"get"}, {"dump", "--format", "xml", "file"}} {
+		if err := run(args, &stdout); !errors.Is(err, errUsage) && !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected a usage error for %v, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Command roarindexd serves named RoarIndex[string, string] instances over
+// HTTP, see package server for the routes. With -resp-addr one of them is
+// also served to Redis clients, see package resp for the commands, and with
+// -grpc-addr over gRPC, see package remote.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/thisisdevelopment/roarindex"
+	"github.com/thisisdevelopment/roarindex/remote"
+	"github.com/thisisdevelopment/roarindex/resp"
+	"github.com/thisisdevelopment/roarindex/server"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	respAddr := flag.String("resp-addr", "", "address to listen on for Redis clients, disabled when empty")
+	respIndex := flag.String("resp-index", "default", "name of the index served to Redis clients")
+	grpcAddr := flag.String("grpc-addr", "", "address to listen on for gRPC clients, disabled when empty")
+	grpcIndex := flag.String("grpc-index", "default", "name of the index served to gRPC clients")
+	flag.Parse()
+
+	api := server.New()
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           api,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if *respAddr != "" {
+		respSrv := resp.NewServer(indexOrCreate(api, *respIndex))
+		defer respSrv.Close()
+		go func() {
+			log.Printf("roarindexd serving index %q to Redis clients on %s", *respIndex, *respAddr)
+			if err := respSrv.ListenAndServe(*respAddr); err != nil && !errors.Is(err, net.ErrClosed) {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	if *grpcAddr != "" {
+		l, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		grpcSrv := grpc.NewServer()
+		remote.NewServer(indexOrCreate(api, *grpcIndex)).Register(grpcSrv)
+		defer grpcSrv.GracefulStop()
+		go func() {
+			log.Printf("roarindexd serving index %q to gRPC clients on %s", *grpcIndex, *grpcAddr)
+			if err := grpcSrv.Serve(l); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("roarindexd listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
+
+// indexOrCreate returns the index registered under name, registering a new
+// one when it does not exist.
+func indexOrCreate(api *server.Server, name string) *roarindex.RoarIndex[string, string] {
+	index, err := api.Index(name)
+	if err != nil {
+		index = roarindex.NewRoarIndex[string, string]()
+		api.Add(name, index)
+	}
+	return index
+}
@@ -0,0 +1,159 @@
+package roarindex
+
+import (
+	"context"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// ctxCheckInterval is the number of entries visited between checks for
+// cancellation.
+const ctxCheckInterval = 1024
+
+// GetMapCtx is GetMap returning ctx.Err() once ctx is done.
+func (om *RoarIndex[K, V]) GetMapCtx(ctx context.Context, key K) ([]V, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	keyID, keyExists := om.backend.KeyID(key)
+	if !keyExists {
+		return nil, ErrKeyNotFound
+	}
+
+	bm, exists := om.backend.Bitmap(keyID)
+	if !exists {
+		return nil, nil // No values associated
+	}
+	values, err := om.valuesOfCtx(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	om.sortValues(values)
+	return values, nil
+}
+
+// KeysCtx is Keys returning ctx.Err() once ctx is done.
+func (om *RoarIndex[K, V]) KeysCtx(ctx context.Context) ([]K, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	if om.order != OrderNone {
+		return om.keysInOrderCtx(ctx)
+	}
+
+	var err error
+	keys := make([]K, 0, om.backend.KeyCount())
+	om.backend.RangeKeys(func(key K, _ uint32) bool {
+		if len(keys)%ctxCheckInterval == 0 {
+			if err = ctx.Err(); err != nil {
+				return false
+			}
+		}
+		keys = append(keys, key)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ValuesCtx is Values returning ctx.Err() once ctx is done.
+func (om *RoarIndex[K, V]) ValuesCtx(ctx context.Context) ([]V, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	if om.order != OrderNone {
+		return om.valuesInOrderCtx(ctx)
+	}
+
+	var err error
+	values := make([]V, 0, om.backend.ValueCount())
+	om.backend.RangeValues(func(value V, _ uint32) bool {
+		if len(values)%ctxCheckInterval == 0 {
+			if err = ctx.Err(); err != nil {
+				return false
+			}
+		}
+		values = append(values, value)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// UnionCtx is Union returning ctx.Err() once ctx is done.
+func (om *RoarIndex[K, V]) UnionCtx(ctx context.Context, keys ...K) ([]V, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	return om.valuesOfCtx(ctx, roaring.FastOr(om.bitmapsOf(keys)...))
+}
+
+// IntersectCtx is Intersect returning ctx.Err() once ctx is done.
+func (om *RoarIndex[K, V]) IntersectCtx(ctx context.Context, keys ...K) ([]V, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bitmaps := om.bitmapsOf(keys)
+	if len(bitmaps) < len(keys) || len(bitmaps) == 0 {
+		return nil, nil
+	}
+	return om.valuesOfCtx(ctx, roaring.FastAnd(bitmaps...))
+}
+
+// DifferenceCtx is Difference returning ctx.Err() once ctx is done.
+func (om *RoarIndex[K, V]) DifferenceCtx(ctx context.Context, key K, others ...K) ([]V, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil {
+		return nil, nil
+	}
+	return om.valuesOfCtx(ctx, roaring.AndNot(bm, roaring.FastOr(om.bitmapsOf(others)...)))
+}
+
+// valuesOfCtx is valuesOf returning ctx.Err() once ctx is done. The caller
+// must hold the lock.
+func (om *RoarIndex[K, V]) valuesOfCtx(ctx context.Context, bm *roaring.Bitmap) ([]V, error) {
+	values := make([]V, 0, bm.GetCardinality())
+	it := bm.Iterator()
+	for i := 0; it.HasNext(); i++ {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if value, valueExists := om.backend.Value(it.Next()); valueExists {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
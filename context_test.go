@@ -0,0 +1,85 @@
+package roarindex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// countdownContext is canceled after its Err method was called n times.
+type countdownContext struct {
+	context.Context
+	n int
+}
+
+func (c *countdownContext) Err() error {
+	if c.n <= 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func newContextIndex() *RoarIndex[string, int] {
+	om := NewRoarIndex[string, int]()
+	for i := 0; i < 10*ctxCheckInterval; i++ {
+		om.PushMap("big", i)
+		om.PushMap(fmt.Sprintf("key%d", i), i)
+	}
+	return om
+}
+
+func TestRoarIndexCtxVariants(t *testing.T) {
+	om := newContextIndex()
+
+	calls := map[string]func(ctx context.Context) (int, error){
+		"GetMapCtx": func(ctx context.Context) (int, error) {
+			values, err := om.GetMapCtx(ctx, "big")
+			return len(values), err
+		},
+		"KeysCtx": func(ctx context.Context) (int, error) {
+			keys, err := om.KeysCtx(ctx)
+			return len(keys), err
+		},
+		"ValuesCtx": func(ctx context.Context) (int, error) {
+			values, err := om.ValuesCtx(ctx)
+			return len(values), err
+		},
+		"UnionCtx": func(ctx context.Context) (int, error) {
+			values, err := om.UnionCtx(ctx, "big", "key1")
+			return len(values), err
+		},
+		"IntersectCtx": func(ctx context.Context) (int, error) {
+			values, err := om.IntersectCtx(ctx, "big", "big")
+			return len(values), err
+		},
+		"DifferenceCtx": func(ctx context.Context) (int, error) {
+			values, err := om.DifferenceCtx(ctx, "big", "key1")
+			return len(values), err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if n, err := call(context.Background()); err != nil || n < 9*ctxCheckInterval {
+				t.Errorf("Expected a full result, but got %d items and %v", n, err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if _, err := call(ctx); !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected context.Canceled for a canceled context, but got %v", err)
+			}
+
+			// Canceled while iterating
+			if _, err := call(&countdownContext{Context: context.Background(), n: 3}); !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected context.Canceled while iterating, but got %v", err)
+			}
+		})
+	}
+
+	if _, err := om.GetMapCtx(context.Background(), "nonExistent"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, but got %v", err)
+	}
+}
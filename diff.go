@@ -0,0 +1,167 @@
+package roarindex
+
+import (
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// DiffKind tells how a key differs between two indexes.
+type DiffKind int
+
+const (
+	// DiffOnlyInA is a key only present in the first index.
+	DiffOnlyInA DiffKind = iota
+	// DiffOnlyInB is a key only present in the second index.
+	DiffOnlyInB
+	// DiffChanged is a key present in both indexes with different values.
+	DiffChanged
+)
+
+// DiffEntry is a key that differs between two indexes. Added holds the
+// values only the second index associates with it and Removed the values
+// only the first index does; both are empty unless Kind is DiffChanged.
+type DiffEntry[K comparable, V comparable] struct {
+	Kind    DiffKind
+	Key     K
+	Added   []V
+	Removed []V
+}
+
+// DiffResult lists how two indexes differ.
+type DiffResult[K comparable, V comparable] struct {
+	OnlyInA []K
+	OnlyInB []K
+	Changed []DiffEntry[K, V]
+}
+
+// Equal reports whether the indexes hold the same keys and values.
+func (d DiffResult[K, V]) Equal() bool {
+	return len(d.OnlyInA) == 0 && len(d.OnlyInB) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares the keys and values of a and b.
+func Diff[K comparable, V comparable](a, b *RoarIndex[K, V]) DiffResult[K, V] {
+	var result DiffResult[K, V]
+	DiffFunc(a, b, func(entry DiffEntry[K, V]) bool {
+		switch entry.Kind {
+		case DiffOnlyInA:
+			result.OnlyInA = append(result.OnlyInA, entry.Key)
+		case DiffOnlyInB:
+			result.OnlyInB = append(result.OnlyInB, entry.Key)
+		case DiffChanged:
+			result.Changed = append(result.Changed, entry)
+		}
+		return true
+	})
+	return result
+}
+
+// DiffFunc calls fn for every key that differs between a and b, without
+// collecting the differences, until fn returns false. The keys of a come
+// first in the order they were pushed, followed by the keys only in b.
+// Both indexes are read locked meanwhile, so fn must not modify them.
+//
+// Values are compared with AndNot on bitmaps, after translating the value
+// IDs of b into the ID space of a.
+func DiffFunc[K comparable, V comparable](a, b *RoarIndex[K, V], fn func(DiffEntry[K, V]) bool) {
+	if a == b {
+		return
+	}
+
+	// Same lock order as Merge
+	first, second := a, b
+	if b.id < a.id {
+		first, second = b, a
+	}
+	first.mtx.RLock()
+	defer first.mtx.RUnlock()
+	second.mtx.RLock()
+	defer second.mtx.RUnlock()
+
+	// Value IDs of b translated so far, valueMissing when a does not know
+	// the value
+	const valueMissing = ^uint32(0)
+	valueIDs := make(map[uint32]uint32)
+
+	it := a.keyIDsLocked().Iterator()
+	for it.HasNext() {
+		keyIDA := it.Next()
+		key, exists := a.backend.Key(keyIDA)
+		if !exists {
+			continue
+		}
+
+		keyIDB, exists := b.backend.KeyID(key)
+		if !exists {
+			if !fn(DiffEntry[K, V]{Kind: DiffOnlyInA, Key: key}) {
+				return
+			}
+			continue
+		}
+
+		bmA, _ := a.backend.Bitmap(keyIDA)
+		if bmA == nil {
+			bmA = roaring.NewBitmap()
+		}
+
+		// Translate the values of b, values a does not know are added
+		// regardless of the bitmap of a
+		entry := DiffEntry[K, V]{Kind: DiffChanged, Key: key}
+		translated := roaring.NewBitmap()
+		if bmB, exists := b.backend.Bitmap(keyIDB); exists {
+			valueIt := bmB.Iterator()
+			for valueIt.HasNext() {
+				valueIDB := valueIt.Next()
+				valueIDA, seen := valueIDs[valueIDB]
+				if !seen {
+					valueIDA = valueMissing
+					if value, exists := b.backend.Value(valueIDB); exists {
+						if id, exists := a.backend.ValueID(value); exists {
+							valueIDA = id
+						}
+					}
+					valueIDs[valueIDB] = valueIDA
+				}
+
+				if valueIDA == valueMissing {
+					value, _ := b.backend.Value(valueIDB)
+					entry.Added = append(entry.Added, value)
+					continue
+				}
+				translated.Add(valueIDA)
+			}
+		}
+
+		entry.Added = append(entry.Added, a.valuesOf(roaring.AndNot(translated, bmA))...)
+		entry.Removed = a.valuesOf(roaring.AndNot(bmA, translated))
+		if len(entry.Added) == 0 && len(entry.Removed) == 0 {
+			continue
+		}
+		if !fn(entry) {
+			return
+		}
+	}
+
+	it = b.keyIDsLocked().Iterator()
+	for it.HasNext() {
+		key, exists := b.backend.Key(it.Next())
+		if !exists {
+			continue
+		}
+		if _, exists := a.backend.KeyID(key); exists {
+			continue
+		}
+		if !fn(DiffEntry[K, V]{Kind: DiffOnlyInB, Key: key}) {
+			return
+		}
+	}
+}
+
+// keyIDsLocked returns the IDs of all keys. The caller must hold the lock.
+func (om *RoarIndex[K, V]) keyIDsLocked() *roaring.Bitmap {
+	keyIDs := roaring.NewBitmap()
+	om.backend.RangeKeys(func(_ K, keyID uint32) bool {
+		keyIDs.Add(keyID)
+		return true
+	})
+	return keyIDs
+}
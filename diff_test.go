@@ -0,0 +1,89 @@
+package roarindex
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := NewRoarIndex[string, string]()
+	a.PushMap("same", "value1")
+	a.PushMap("changed", "value1")
+	a.PushMap("changed", "value2")
+	a.PushMap("onlyA", "value1")
+
+	// Push in another order so both indexes assign different IDs
+	b := NewRoarIndex[string, string]()
+	b.PushMap("onlyB", "value9")
+	b.PushMap("changed", "value3")
+	b.PushMap("changed", "value2")
+	b.PushMap("changed", "value4")
+	b.PushMap("same", "value1")
+
+	result := Diff(a, b)
+	if !reflect.DeepEqual(result.OnlyInA, []string{"onlyA"}) {
+		t.Errorf("Expected [onlyA] only in a, but got %v", result.OnlyInA)
+	}
+	if !reflect.DeepEqual(result.OnlyInB, []string{"onlyB"}) {
+		t.Errorf("Expected [onlyB] only in b, but got %v", result.OnlyInB)
+	}
+	if len(result.Changed) != 1 {
+		t.Fatalf("Expected 1 changed key, but got %v", result.Changed)
+	}
+
+	changed := result.Changed[0]
+	slices.Sort(changed.Added)
+	if changed.Key != "changed" || !reflect.DeepEqual(changed.Added, []string{"value3", "value4"}) || !reflect.DeepEqual(changed.Removed, []string{"value1"}) {
+		t.Errorf("Unexpected changed entry %+v", changed)
+	}
+	if result.Equal() {
+		t.Errorf("Expected the indexes to differ")
+	}
+
+	// The inverse diff swaps the sides
+	inverse := Diff(b, a)
+	if !reflect.DeepEqual(inverse.OnlyInA, []string{"onlyB"}) || !reflect.DeepEqual(inverse.OnlyInB, []string{"onlyA"}) {
+		t.Errorf("Unexpected inverse diff %+v", inverse)
+	}
+	if len(inverse.Changed) != 1 || !reflect.DeepEqual(inverse.Changed[0].Added, []string{"value1"}) {
+		t.Errorf("Unexpected inverse changes %+v", inverse.Changed)
+	}
+
+	if !Diff(a, a).Equal() {
+		t.Errorf("Expected an index to equal itself")
+	}
+}
+
+func TestDiffEqualWithDifferentIDs(t *testing.T) {
+	a := NewRoarIndex[int, int]()
+	b := NewRoarIndex[int, int]()
+	for i := 0; i < 1000; i++ {
+		a.PushMap(i%10, i)
+		b.PushMap(9-i%10, 999-i)
+	}
+	if result := Diff(a, b); !result.Equal() {
+		t.Errorf("Expected equal indexes, but got %+v", result)
+	}
+}
+
+func TestDiffFuncStops(t *testing.T) {
+	a := NewRoarIndex[string, string]()
+	b := NewRoarIndex[string, string]()
+	for _, key := range []string{"key1", "key2", "key3"} {
+		a.PushMap(key, "value1")
+		b.PushMap(key, "value2")
+	}
+
+	var seen []DiffEntry[string, string]
+	DiffFunc(a, b, func(entry DiffEntry[string, string]) bool {
+		seen = append(seen, entry)
+		return len(seen) < 2
+	})
+	if len(seen) != 2 {
+		t.Errorf("Expected DiffFunc to stop after 2 entries, but got %d", len(seen))
+	}
+	if seen[0].Key != "key1" || seen[1].Key != "key2" {
+		t.Errorf("Expected the keys in push order, but got %v", seen)
+	}
+}
@@ -0,0 +1,139 @@
+package diskbackend
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"reflect"
+)
+
+// ErrInvalidEncoding is returned when stored bytes cannot be decoded.
+var ErrInvalidEncoding = errors.New("invalid encoding")
+
+// ErrLossyEncoding is returned by DefaultCodec for a value that
+// encoding/gob cannot round trip exactly, such as a struct with unexported
+// fields. Such values need a Codec of their own.
+var ErrLossyEncoding = errors.New("value does not round trip through encoding/gob")
+
+// Codec converts keys or values to and from bytes. Equal inputs must encode
+// to equal bytes, as the encoding is used to look them up.
+type Codec[T any] interface {
+	Encode(v T) ([]byte, error)
+	Decode(b []byte) (T, error)
+}
+
+// DefaultCodec encodes strings as their bytes, booleans and integers as
+// fixed size big-endian numbers and everything else with encoding/gob.
+// Values encoded with encoding/gob must decode to a value equal to the
+// original, as two values that gob can't tell apart would share an entry.
+type DefaultCodec[T any] struct{}
+
+// Encode implements Codec.
+func (DefaultCodec[T]) Encode(v T) ([]byte, error) {
+	switch x := any(v).(type) {
+	case string:
+		return []byte(x), nil
+	case bool:
+		if x {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case int:
+		return binary.BigEndian.AppendUint64(nil, uint64(x)), nil
+	case int8:
+		return []byte{byte(x)}, nil
+	case int16:
+		return binary.BigEndian.AppendUint16(nil, uint16(x)), nil
+	case int32:
+		return binary.BigEndian.AppendUint32(nil, uint32(x)), nil
+	case int64:
+		return binary.BigEndian.AppendUint64(nil, uint64(x)), nil
+	case uint:
+		return binary.BigEndian.AppendUint64(nil, uint64(x)), nil
+	case uint8:
+		return []byte{x}, nil
+	case uint16:
+		return binary.BigEndian.AppendUint16(nil, x), nil
+	case uint32:
+		return binary.BigEndian.AppendUint32(nil, x), nil
+	case uint64:
+		return binary.BigEndian.AppendUint64(nil, x), nil
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	if reflect.TypeFor[T]().Comparable() {
+		var decoded T
+		err := gob.NewDecoder(bytes.NewReader(buf.Bytes())).Decode(&decoded)
+		if err != nil || any(decoded) != any(v) {
+			return nil, ErrLossyEncoding
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode implements Codec.
+func (DefaultCodec[T]) Decode(b []byte) (T, error) {
+	var v T
+	var err error
+	switch p := any(&v).(type) {
+	case *string:
+		*p = string(b)
+	case *bool:
+		err = checkLen(b, 1)
+		*p = err == nil && b[0] != 0
+	case *int:
+		if err = checkLen(b, 8); err == nil {
+			*p = int(binary.BigEndian.Uint64(b))
+		}
+	case *int8:
+		if err = checkLen(b, 1); err == nil {
+			*p = int8(b[0])
+		}
+	case *int16:
+		if err = checkLen(b, 2); err == nil {
+			*p = int16(binary.BigEndian.Uint16(b))
+		}
+	case *int32:
+		if err = checkLen(b, 4); err == nil {
+			*p = int32(binary.BigEndian.Uint32(b))
+		}
+	case *int64:
+		if err = checkLen(b, 8); err == nil {
+			*p = int64(binary.BigEndian.Uint64(b))
+		}
+	case *uint:
+		if err = checkLen(b, 8); err == nil {
+			*p = uint(binary.BigEndian.Uint64(b))
+		}
+	case *uint8:
+		if err = checkLen(b, 1); err == nil {
+			*p = b[0]
+		}
+	case *uint16:
+		if err = checkLen(b, 2); err == nil {
+			*p = binary.BigEndian.Uint16(b)
+		}
+	case *uint32:
+		if err = checkLen(b, 4); err == nil {
+			*p = binary.BigEndian.Uint32(b)
+		}
+	case *uint64:
+		if err = checkLen(b, 8); err == nil {
+			*p = binary.BigEndian.Uint64(b)
+		}
+	default:
+		err = gob.NewDecoder(bytes.NewReader(b)).Decode(&v)
+	}
+	return v, err
+}
+
+func checkLen(b []byte, n int) error {
+	if len(b) != n {
+		return ErrInvalidEncoding
+	}
+	return nil
+}
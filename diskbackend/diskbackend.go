@@ -0,0 +1,667 @@
+// Package diskbackend provides a roarindex.Backend that keeps the bitmaps
+// and the key and value dictionaries in a local bbolt file, so a RoarIndex
+// can grow well beyond the available memory.
+//
+// Writes are buffered in memory and written to disk in batches; reads go
+// through the buffer and a bounded cache of recently used bitmaps.
+package diskbackend
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	roaring "github.com/RoaringBitmap/roaring"
+	"github.com/thisisdevelopment/roarindex"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrClosed is returned when the Backend is used after Close.
+var ErrClosed = errors.New("backend closed")
+
+var (
+	bucketKeys     = []byte("keys")
+	bucketKeyIDs   = []byte("keyids")
+	bucketValues   = []byte("values")
+	bucketValueIDs = []byte("valueids")
+	bucketBitmaps  = []byte("bitmaps")
+)
+
+// rangeChunkSize is the number of entries read from disk per transaction
+// while ranging, so no transaction is held open during callbacks.
+const rangeChunkSize = 1024
+
+// Options configures a Backend. The zero value is ready to use.
+type Options[K comparable, V comparable] struct {
+	// CacheSize is the maximum number of bitmaps kept in memory, 1024 when 0.
+	CacheSize int
+	// BatchSize is the number of buffered writes that triggers a write to
+	// disk, 4096 when 0.
+	BatchSize int
+	// NoSync skips fsync after each batch, trading durability for speed.
+	NoSync bool
+	// KeyCodec encodes keys, DefaultCodec when nil.
+	KeyCodec Codec[K]
+	// ValueCodec encodes values, DefaultCodec when nil.
+	ValueCodec Codec[V]
+}
+
+var _ roarindex.Backend[string, string] = (*Backend[string, string])(nil)
+
+// Backend is a roarindex.Backend stored in a bbolt file.
+//
+// The roarindex.Backend methods cannot return errors: the first error hit
+// while reading or writing the file is kept and reported by Err, Sync and
+// Close, and the failed lookup reports the entry as missing.
+type Backend[K comparable, V comparable] struct {
+	mu  sync.Mutex
+	db  *bolt.DB
+	err error
+
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
+	batchSize  int
+	cacheSize  int
+
+	keyCount   int
+	valueCount int
+
+	// Buffered writes not yet on disk
+	keys    dictionary[K]
+	values  dictionary[V]
+	bitmaps map[uint32]*roaring.Bitmap
+	deleted map[uint32]struct{}
+	pending int
+
+	// LRU cache of bitmaps that are on disk
+	cache   map[uint32]*list.Element
+	lru     *list.List
+	scratch bytes.Buffer
+}
+
+// cacheEntry is an element of the LRU list.
+type cacheEntry struct {
+	keyID uint32
+	bm    *roaring.Bitmap
+}
+
+// dictionary buffers writes to a key or value dictionary.
+type dictionary[T comparable] struct {
+	toID    map[T]uint32
+	fromID  map[uint32]T
+	deleted map[uint32]T
+}
+
+func newDictionary[T comparable]() dictionary[T] {
+	return dictionary[T]{
+		toID:    make(map[T]uint32),
+		fromID:  make(map[uint32]T),
+		deleted: make(map[uint32]T),
+	}
+}
+
+// Open opens or creates the Backend stored at path. opts may be nil.
+func Open[K comparable, V comparable](path string, opts *Options[K, V]) (*Backend[K, V], error) {
+	if opts == nil {
+		opts = &Options[K, V]{}
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second, NoSync: opts.NoSync})
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Backend[K, V]{
+		db:         db,
+		keyCodec:   opts.KeyCodec,
+		valueCodec: opts.ValueCodec,
+		batchSize:  opts.BatchSize,
+		cacheSize:  opts.CacheSize,
+		keys:       newDictionary[K](),
+		values:     newDictionary[V](),
+		bitmaps:    make(map[uint32]*roaring.Bitmap),
+		deleted:    make(map[uint32]struct{}),
+		cache:      make(map[uint32]*list.Element),
+		lru:        list.New(),
+	}
+	if b.keyCodec == nil {
+		b.keyCodec = DefaultCodec[K]{}
+	}
+	if b.valueCodec == nil {
+		b.valueCodec = DefaultCodec[V]{}
+	}
+	if b.batchSize <= 0 {
+		b.batchSize = 4096
+	}
+	if b.cacheSize <= 0 {
+		b.cacheSize = 1024
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketKeys, bucketKeyIDs, bucketValues, bucketValueIDs, bucketBitmaps} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		b.keyCount = tx.Bucket(bucketKeyIDs).Stats().KeyN
+		b.valueCount = tx.Bucket(bucketValueIDs).Stats().KeyN
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Err returns the first error the Backend ran into, if any.
+func (b *Backend[K, V]) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.err
+}
+
+// Sync writes all buffered writes to disk.
+func (b *Backend[K, V]) Sync() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.flush()
+	return b.err
+}
+
+// Close writes all buffered writes to disk and closes the file.
+func (b *Backend[K, V]) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.db == nil {
+		return ErrClosed
+	}
+	b.flush()
+	if err := b.db.Close(); err != nil && b.err == nil {
+		b.err = err
+	}
+	b.db = nil
+	return b.err
+}
+
+// Bitmap implements roarindex.Backend.
+func (b *Backend[K, V]) Bitmap(keyID uint32) (*roaring.Bitmap, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if bm, exists := b.bitmaps[keyID]; exists {
+		return bm, true
+	}
+	if _, deleted := b.deleted[keyID]; deleted {
+		return nil, false
+	}
+	if elem, cached := b.cache[keyID]; cached {
+		b.lru.MoveToFront(elem)
+		return elem.Value.(*cacheEntry).bm, true
+	}
+
+	bm, exists := b.readBitmap(keyID)
+	if exists {
+		b.cachePut(keyID, bm)
+	}
+	return bm, exists
+}
+
+// PutBitmap implements roarindex.Backend.
+func (b *Backend[K, V]) PutBitmap(keyID uint32, bm *roaring.Bitmap) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.cacheRemove(keyID)
+	delete(b.deleted, keyID)
+	b.bitmaps[keyID] = bm
+	b.written()
+}
+
+// DeleteBitmap implements roarindex.Backend.
+func (b *Backend[K, V]) DeleteBitmap(keyID uint32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.cacheRemove(keyID)
+	delete(b.bitmaps, keyID)
+	b.deleted[keyID] = struct{}{}
+	b.written()
+}
+
+// RangeBitmaps implements roarindex.Backend.
+func (b *Backend[K, V]) RangeBitmaps(fn func(keyID uint32, bm *roaring.Bitmap) bool) {
+	b.mu.Lock()
+	buffered := make(map[uint32]*roaring.Bitmap, len(b.bitmaps))
+	for keyID, bm := range b.bitmaps {
+		buffered[keyID] = bm
+	}
+	deleted := make(map[uint32]struct{}, len(b.deleted))
+	for keyID := range b.deleted {
+		deleted[keyID] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	for keyID, bm := range buffered {
+		if !fn(keyID, bm) {
+			return
+		}
+	}
+
+	b.rangeBucket(bucketBitmaps, func(k, v []byte) bool {
+		keyID := binary.BigEndian.Uint32(k)
+		if _, exists := buffered[keyID]; exists {
+			return true
+		}
+		if _, exists := deleted[keyID]; exists {
+			return true
+		}
+		bm := roaring.NewBitmap()
+		if err := bm.UnmarshalBinary(v); err != nil {
+			b.setErr(err)
+			return false
+		}
+		return fn(keyID, bm)
+	})
+}
+
+// KeyID implements roarindex.Backend.
+func (b *Backend[K, V]) KeyID(key K) (uint32, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return lookupID(b, &b.keys, bucketKeys, b.keyCodec, key)
+}
+
+// Key implements roarindex.Backend.
+func (b *Backend[K, V]) Key(keyID uint32) (K, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return lookupItem(b, &b.keys, bucketKeyIDs, b.keyCodec, keyID)
+}
+
+// PutKey implements roarindex.Backend.
+func (b *Backend[K, V]) PutKey(key K, keyID uint32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.keys.toID[key] = keyID
+	b.keys.fromID[keyID] = key
+	b.keyCount++
+	b.written()
+}
+
+// DeleteKey implements roarindex.Backend.
+func (b *Backend[K, V]) DeleteKey(key K, keyID uint32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.keys.toID, key)
+	delete(b.keys.fromID, keyID)
+	b.keys.deleted[keyID] = key
+	b.keyCount--
+	b.written()
+}
+
+// RangeKeys implements roarindex.Backend.
+func (b *Backend[K, V]) RangeKeys(fn func(key K, keyID uint32) bool) {
+	rangeDictionary(b, &b.keys, bucketKeyIDs, b.keyCodec, fn)
+}
+
+// KeyCount implements roarindex.Backend.
+func (b *Backend[K, V]) KeyCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.keyCount
+}
+
+// ValueID implements roarindex.Backend.
+func (b *Backend[K, V]) ValueID(value V) (uint32, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return lookupID(b, &b.values, bucketValues, b.valueCodec, value)
+}
+
+// Value implements roarindex.Backend.
+func (b *Backend[K, V]) Value(valueID uint32) (V, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return lookupItem(b, &b.values, bucketValueIDs, b.valueCodec, valueID)
+}
+
+// PutValue implements roarindex.Backend.
+func (b *Backend[K, V]) PutValue(value V, valueID uint32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.values.toID[value] = valueID
+	b.values.fromID[valueID] = value
+	b.valueCount++
+	b.written()
+}
+
+// RangeValues implements roarindex.Backend.
+func (b *Backend[K, V]) RangeValues(fn func(value V, valueID uint32) bool) {
+	rangeDictionary(b, &b.values, bucketValueIDs, b.valueCodec, fn)
+}
+
+// ValueCount implements roarindex.Backend.
+func (b *Backend[K, V]) ValueCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.valueCount
+}
+
+// setErr records err unless an earlier error was recorded.
+func (b *Backend[K, V]) setErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// written counts a buffered write and flushes the buffer once it is full.
+// The caller must hold mu.
+func (b *Backend[K, V]) written() {
+	b.pending++
+	if b.pending >= b.batchSize {
+		b.flush()
+	}
+}
+
+// flush writes all buffered writes to disk in one transaction. On failure
+// the writes stay buffered. The caller must hold mu.
+func (b *Backend[K, V]) flush() {
+	if b.pending == 0 {
+		return
+	}
+	if b.db == nil {
+		if b.err == nil {
+			b.err = ErrClosed
+		}
+		return
+	}
+
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		if err := flushDictionary(tx, &b.keys, bucketKeys, bucketKeyIDs, b.keyCodec); err != nil {
+			return err
+		}
+		if err := flushDictionary(tx, &b.values, bucketValues, bucketValueIDs, b.valueCodec); err != nil {
+			return err
+		}
+
+		bitmaps := tx.Bucket(bucketBitmaps)
+		for keyID := range b.deleted {
+			if err := bitmaps.Delete(idBytes(keyID)); err != nil {
+				return err
+			}
+		}
+		for keyID, bm := range b.bitmaps {
+			b.scratch.Reset()
+			if _, err := bm.WriteTo(&b.scratch); err != nil {
+				return err
+			}
+			if err := bitmaps.Put(idBytes(keyID), bytes.Clone(b.scratch.Bytes())); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return
+	}
+
+	for keyID, bm := range b.bitmaps {
+		b.cachePut(keyID, bm)
+	}
+	b.keys = newDictionary[K]()
+	b.values = newDictionary[V]()
+	b.bitmaps = make(map[uint32]*roaring.Bitmap)
+	b.deleted = make(map[uint32]struct{})
+	b.pending = 0
+}
+
+// readBitmap reads a bitmap from disk. The caller must hold mu.
+func (b *Backend[K, V]) readBitmap(keyID uint32) (*roaring.Bitmap, bool) {
+	if b.db == nil {
+		return nil, false
+	}
+
+	var bm *roaring.Bitmap
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketBitmaps).Get(idBytes(keyID))
+		if data == nil {
+			return nil
+		}
+		bm = roaring.NewBitmap()
+		_, err := bm.ReadFrom(bytes.NewReader(data))
+		return err
+	})
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return nil, false
+	}
+	return bm, bm != nil
+}
+
+// cachePut adds a bitmap to the cache, evicting the least recently used
+// bitmap when the cache is full. The caller must hold mu.
+func (b *Backend[K, V]) cachePut(keyID uint32, bm *roaring.Bitmap) {
+	if elem, cached := b.cache[keyID]; cached {
+		elem.Value.(*cacheEntry).bm = bm
+		b.lru.MoveToFront(elem)
+		return
+	}
+
+	b.cache[keyID] = b.lru.PushFront(&cacheEntry{keyID: keyID, bm: bm})
+	for b.lru.Len() > b.cacheSize {
+		b.cacheRemove(b.lru.Back().Value.(*cacheEntry).keyID)
+	}
+}
+
+// cacheRemove drops a bitmap from the cache. The caller must hold mu.
+func (b *Backend[K, V]) cacheRemove(keyID uint32) {
+	if elem, cached := b.cache[keyID]; cached {
+		b.lru.Remove(elem)
+		delete(b.cache, keyID)
+	}
+}
+
+// rangeBucket calls fn for every entry of a bucket, reading the bucket in
+// chunks so fn runs outside of any transaction.
+func (b *Backend[K, V]) rangeBucket(name []byte, fn func(k, v []byte) bool) {
+	b.mu.Lock()
+	db := b.db
+	b.mu.Unlock()
+	if db == nil {
+		return
+	}
+
+	var after []byte
+	for {
+		var keys, values [][]byte
+		err := db.View(func(tx *bolt.Tx) error {
+			c := tx.Bucket(name).Cursor()
+			k, v := c.First()
+			if after != nil {
+				k, v = c.Seek(after)
+				if bytes.Equal(k, after) {
+					k, v = c.Next()
+				}
+			}
+			for ; k != nil && len(keys) < rangeChunkSize; k, v = c.Next() {
+				keys = append(keys, bytes.Clone(k))
+				values = append(values, bytes.Clone(v))
+			}
+			return nil
+		})
+		if err != nil {
+			b.setErr(err)
+			return
+		}
+
+		for i := range keys {
+			if !fn(keys[i], values[i]) {
+				return
+			}
+		}
+		if len(keys) < rangeChunkSize {
+			return
+		}
+		after = keys[len(keys)-1]
+	}
+}
+
+// lookupID returns the ID of item from the buffer or from disk. The caller
+// must hold mu.
+func lookupID[K comparable, V comparable, T comparable](b *Backend[K, V], d *dictionary[T], name []byte, codec Codec[T], item T) (uint32, bool) {
+	if id, exists := d.toID[item]; exists {
+		return id, true
+	}
+	if b.db == nil {
+		return 0, false
+	}
+
+	encoded, err := codec.Encode(item)
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return 0, false
+	}
+
+	var id uint32
+	var exists bool
+	err = b.db.View(func(tx *bolt.Tx) error {
+		if data := tx.Bucket(name).Get(encoded); data != nil {
+			id, exists = binary.BigEndian.Uint32(data), true
+		}
+		return nil
+	})
+	if err != nil && b.err == nil {
+		b.err = err
+	}
+	if _, deleted := d.deleted[id]; deleted {
+		return 0, false
+	}
+	return id, exists
+}
+
+// lookupItem returns the item with an ID from the buffer or from disk. The
+// caller must hold mu.
+func lookupItem[K comparable, V comparable, T comparable](b *Backend[K, V], d *dictionary[T], name []byte, codec Codec[T], id uint32) (T, bool) {
+	var item T
+	if item, exists := d.fromID[id]; exists {
+		return item, true
+	}
+	if _, deleted := d.deleted[id]; deleted || b.db == nil {
+		return item, false
+	}
+
+	var exists bool
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(name).Get(idBytes(id))
+		if data == nil {
+			return nil
+		}
+		var err error
+		item, err = codec.Decode(data)
+		exists = err == nil
+		return err
+	})
+	if err != nil && b.err == nil {
+		b.err = err
+	}
+	return item, exists
+}
+
+// rangeDictionary calls fn for the buffered items of a dictionary and then
+// for the items on disk that were not deleted since.
+func rangeDictionary[K comparable, V comparable, T comparable](b *Backend[K, V], d *dictionary[T], name []byte, codec Codec[T], fn func(item T, id uint32) bool) {
+	b.mu.Lock()
+	buffered := make(map[uint32]T, len(d.fromID))
+	for id, item := range d.fromID {
+		buffered[id] = item
+	}
+	deleted := make(map[uint32]struct{}, len(d.deleted))
+	for id := range d.deleted {
+		deleted[id] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	for id, item := range buffered {
+		if !fn(item, id) {
+			return
+		}
+	}
+
+	b.rangeBucket(name, func(k, v []byte) bool {
+		id := binary.BigEndian.Uint32(k)
+		if _, exists := deleted[id]; exists {
+			return true
+		}
+		item, err := codec.Decode(v)
+		if err != nil {
+			b.setErr(err)
+			return false
+		}
+		return fn(item, id)
+	})
+}
+
+// flushDictionary writes the buffered writes of a dictionary, deletes
+// first so an item deleted and added again ends up with its new ID.
+func flushDictionary[T comparable](tx *bolt.Tx, d *dictionary[T], toIDName, fromIDName []byte, codec Codec[T]) error {
+	toID := tx.Bucket(toIDName)
+	fromID := tx.Bucket(fromIDName)
+
+	for id, item := range d.deleted {
+		encoded, err := codec.Encode(item)
+		if err != nil {
+			return err
+		}
+		if err := toID.Delete(encoded); err != nil {
+			return err
+		}
+		if err := fromID.Delete(idBytes(id)); err != nil {
+			return err
+		}
+	}
+	for item, id := range d.toID {
+		encoded, err := codec.Encode(item)
+		if err != nil {
+			return err
+		}
+		if err := toID.Put(encoded, idBytes(id)); err != nil {
+			return err
+		}
+		if err := fromID.Put(idBytes(id), encoded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// idBytes encodes an ID big-endian, so IDs sort numerically on disk.
+func idBytes(id uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, id)
+}
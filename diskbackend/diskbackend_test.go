@@ -0,0 +1,181 @@
+package diskbackend
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/thisisdevelopment/roarindex"
+	"github.com/thisisdevelopment/roarindex/backendtest"
+)
+
+func openTemp[K comparable, V comparable](t *testing.T, opts *Options[K, V]) (*Backend[K, V], string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "index.db")
+	b, err := Open[K, V](path, opts)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	t.Cleanup(func() { b.Close() })
+	return b, path
+}
+
+func TestBackendConformance(t *testing.T) {
+	backendtest.Run(t, func() roarindex.Backend[string, string] {
+		b, _ := openTemp[string, string](t, nil)
+		return b
+	})
+}
+
+func TestBackendConformanceSmallBatches(t *testing.T) {
+	// Flush after every write and keep a single bitmap in memory, so the
+	// suite runs against what is on disk
+	backendtest.Run(t, func() roarindex.Backend[string, string] {
+		b, _ := openTemp(t, &Options[string, string]{BatchSize: 1, CacheSize: 1, NoSync: true})
+		return b
+	})
+}
+
+func TestBackendPersistence(t *testing.T) {
+	b, path := openTemp[string, int](t, &Options[string, int]{NoSync: true})
+	om := roarindex.NewRoarIndexWithBackend(b)
+	for i := 0; i < 100; i++ {
+		om.PushMap(fmt.Sprintf("map%d", i%10), i)
+	}
+	om.DeleteMap("map3")
+	if err := b.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	b, err := Open[string, int](path, nil)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer b.Close()
+
+	om = roarindex.NewRoarIndexWithBackend(b)
+	if n := om.Count(); n != 9 {
+		t.Errorf("Expected 9 keys after reopening, got %d", n)
+	}
+	values, err := om.GetMap("map7")
+	if err != nil {
+		t.Errorf("Expected map7 to exist, got error: %v", err)
+	}
+	if !slices.Equal(values, []int{7, 17, 27, 37, 47, 57, 67, 77, 87, 97}) {
+		t.Errorf("Expected the values of map7, got %v", values)
+	}
+	if _, err := om.GetMap("map3"); err != roarindex.ErrKeyNotFound {
+		t.Errorf("Expected map3 to stay deleted, got %v", err)
+	}
+
+	// New IDs continue after the ones on disk
+	om.PushMap("map3", 1000)
+	if om.HasValue("map3", 3) || !om.HasValue("map3", 1000) {
+		t.Errorf("Expected map3 to only hold 1000")
+	}
+	if err := b.Sync(); err != nil {
+		t.Errorf("Sync failed: %v", err)
+	}
+}
+
+func TestBackendLargerThanCache(t *testing.T) {
+	b, _ := openTemp(t, &Options[int, int]{CacheSize: 8, BatchSize: 64, NoSync: true})
+	om := roarindex.NewRoarIndexWithBackend(b)
+
+	for i := 0; i < 5000; i++ {
+		om.PushMap(i%500, i)
+	}
+	if n := b.lru.Len(); n > 8 {
+		t.Errorf("Expected at most 8 cached bitmaps, got %d", n)
+	}
+
+	for key := 0; key < 500; key++ {
+		values, err := om.GetMap(key)
+		if err != nil || len(values) != 10 {
+			t.Fatalf("Expected 10 values for key %d, got %v %v", key, values, err)
+		}
+		if !om.HasValue(key, key+4500) {
+			t.Fatalf("Expected key %d to hold %d", key, key+4500)
+		}
+	}
+	if n := len(om.Keys()); n != 500 {
+		t.Errorf("Expected 500 keys, got %d", n)
+	}
+	if n := len(om.Values()); n != 5000 {
+		t.Errorf("Expected 5000 values, got %d", n)
+	}
+	if err := b.Err(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestBackendStructValues(t *testing.T) {
+	type item struct {
+		ID   int
+		Name string
+	}
+	b, _ := openTemp[string, item](t, &Options[string, item]{BatchSize: 1, NoSync: true})
+	om := roarindex.NewRoarIndexWithBackend(b)
+	om.PushMap("map1", item{1, "one"})
+	om.PushMap("map1", item{2, "two"})
+
+	if !om.HasValue("map1", item{2, "two"}) {
+		t.Errorf("Expected map1 to hold item 2")
+	}
+	values, _ := om.GetMap("map1")
+	if !slices.Equal(values, []item{{1, "one"}, {2, "two"}}) {
+		t.Errorf("Expected both items, got %v", values)
+	}
+}
+
+func TestBackendClosed(t *testing.T) {
+	b, _ := openTemp[string, string](t, nil)
+	if err := b.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := b.Close(); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+	if _, exists := b.KeyID("key1"); exists {
+		t.Errorf("Expected no keys after Close")
+	}
+}
+
+func TestDefaultCodec(t *testing.T) {
+	testCodec(t, "hello")
+	testCodec(t, -42)
+	testCodec(t, int8(-8))
+	testCodec(t, uint16(16))
+	testCodec(t, int32(-32))
+	testCodec(t, uint64(1<<63))
+	testCodec(t, true)
+	testCodec(t, [2]float64{1.5, 2.5})
+
+	if _, err := (DefaultCodec[int]{}).Decode([]byte{1, 2}); err != ErrInvalidEncoding {
+		t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+	}
+
+	// gob skips unexported fields, so these two would encode the same
+	type point struct {
+		X int
+		y int
+	}
+	testCodec(t, point{X: 1})
+	if _, err := (DefaultCodec[point]{}).Encode(point{X: 1, y: 2}); err != ErrLossyEncoding {
+		t.Errorf("Expected ErrLossyEncoding, got %v", err)
+	}
+}
+
+func testCodec[T comparable](t *testing.T, v T) {
+	t.Helper()
+	codec := DefaultCodec[T]{}
+	encoded, err := codec.Encode(v)
+	if err != nil {
+		t.Fatalf("Encode(%v) failed: %v", v, err)
+	}
+	decoded, err := codec.Decode(encoded)
+	if err != nil || decoded != v {
+		t.Errorf("Expected %v to round trip, got %v %v", v, decoded, err)
+	}
+}
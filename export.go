@@ -0,0 +1,427 @@
+package roarindex
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// ExportOptions configures ExportCSV. The zero value is ready to use.
+type ExportOptions[K comparable, V comparable] struct {
+	// PerKey writes one row per key with its values joined by Delimiter,
+	// instead of one row per key-value pair.
+	PerKey bool
+	// Delimiter joins the values of a key when PerKey is set, "|" when
+	// empty.
+	Delimiter string
+	// Comma is the CSV field separator, ',' when 0.
+	Comma rune
+	// Header writes a key,value header row first.
+	Header bool
+	// FormatKey and FormatValue convert a key or value to text. When nil,
+	// strings are written as is and other types are encoded as JSON, the
+	// inverse of the ImportOptions defaults.
+	FormatKey   func(K) string
+	FormatValue func(V) string
+}
+
+// exportEntry is a key with its values as written by ExportJSON and
+// ExportJSONL.
+type exportEntry[K comparable, V comparable] struct {
+	Key    K   `json:"key"`
+	Values []V `json:"values"`
+}
+
+// ExportJSON writes the RoarIndex to w as a JSON array with a
+// {"key": ..., "values": [...]} object per key.
+func (om *RoarIndex[K, V]) ExportJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString("["); err != nil {
+		return err
+	}
+
+	first := true
+	err := om.exportEach(func(key K, values []V) error {
+		if !first {
+			bw.WriteString(",")
+		}
+		first = false
+		bw.WriteString("\n")
+
+		data, err := json.Marshal(exportEntry[K, V]{Key: key, Values: values})
+		if err != nil {
+			return err
+		}
+		_, err = bw.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if !first {
+		bw.WriteString("\n")
+	}
+	bw.WriteString("]\n")
+	return bw.Flush()
+}
+
+// ExportJSONL writes the RoarIndex to w as JSON Lines with a
+// {"key": ..., "values": [...]} object per key, which ImportJSONL reads
+// back with a ValueField of "values".
+func (om *RoarIndex[K, V]) ExportJSONL(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	err := om.exportEach(func(key K, values []V) error {
+		return enc.Encode(exportEntry[K, V]{Key: key, Values: values})
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ExportCSV writes the RoarIndex to w as CSV, with a key,value row per pair
+// or, with PerKey, a row per key. Keys without values are only written
+// with PerKey. Keys or values that fail to encode as JSON end the export
+// with an error.
+func (om *RoarIndex[K, V]) ExportCSV(w io.Writer, opts *ExportOptions[K, V]) error {
+	if opts == nil {
+		opts = &ExportOptions[K, V]{}
+	}
+	formatKey, formatValue := formatText[K], formatText[V]
+	if opts.FormatKey != nil {
+		formatKey = infallible(opts.FormatKey)
+	}
+	if opts.FormatValue != nil {
+		formatValue = infallible(opts.FormatValue)
+	}
+	delimiter := opts.Delimiter
+	if delimiter == "" {
+		delimiter = "|"
+	}
+
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+	if opts.Header {
+		if err := cw.Write([]string{"key", "value"}); err != nil {
+			return err
+		}
+	}
+
+	var texts []string
+	err := om.exportEach(func(key K, values []V) error {
+		keyText, err := formatKey(key)
+		if err != nil {
+			return fmt.Errorf("key %v: %w", key, err)
+		}
+		if !opts.PerKey {
+			for _, value := range values {
+				valueText, err := formatValue(value)
+				if err != nil {
+					return fmt.Errorf("value %v: %w", value, err)
+				}
+				if err := cw.Write([]string{keyText, valueText}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		texts = texts[:0]
+		for _, value := range values {
+			valueText, err := formatValue(value)
+			if err != nil {
+				return fmt.Errorf("value %v: %w", value, err)
+			}
+			texts = append(texts, valueText)
+		}
+		return cw.Write([]string{keyText, strings.Join(texts, delimiter)})
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportEach calls fn for every key with its values in GetMap order, in
+// the order the keys were first pushed. The values slice is reused between
+// calls. The read lock is held until all keys were visited.
+func (om *RoarIndex[K, V]) exportEach(fn func(key K, values []V) error) error {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	var values []V
+	it := om.keyIDsLocked().Iterator()
+	for it.HasNext() {
+		keyID := it.Next()
+		key, exists := om.backend.Key(keyID)
+		if !exists {
+			continue
+		}
+
+		values = values[:0]
+		if bm, exists := om.backend.Bitmap(keyID); exists {
+			valueIt := bm.Iterator()
+			for valueIt.HasNext() {
+				if value, exists := om.backend.Value(valueIt.Next()); exists {
+					values = append(values, value)
+				}
+			}
+			om.sortValues(values)
+		}
+		if err := fn(key, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatText is the default format function: strings are used as is,
+// other types are encoded as JSON.
+func formatText[T any](t T) (string, error) {
+	if s, ok := any(t).(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// infallible adapts a format function of ExportOptions to formatText.
+func infallible[T any](format func(T) string) func(T) (string, error) {
+	return func(t T) (string, error) {
+		return format(t), nil
+	}
+}
+
+// columnarMagic starts every columnar export, the last byte is the format
+// version.
+var columnarMagic = []byte("ROARCOL\x01")
+
+// columnarGroupSize is the number of pairs per encoded row group.
+const columnarGroupSize = 64 * 1024
+
+// columnarHeader follows the magic bytes and tells how many entries follow.
+type columnarHeader struct {
+	Keys   int
+	Values int
+	Pairs  uint64
+}
+
+type columnarKey[K comparable] struct {
+	ID  uint32
+	Key K
+}
+
+// columnarGroup holds a run of pairs. The key column is run-length encoded
+// and value IDs are delta encoded within each run, keeping the varints gob
+// writes small.
+type columnarGroup struct {
+	KeyIDs      []uint32
+	RunLengths  []uint32
+	ValueDeltas []uint32
+}
+
+// Columnar is the contents of a columnar export: the key and value
+// dictionaries and a column of key IDs and a column of value IDs with an
+// entry per key-value pair.
+type Columnar[K comparable, V comparable] struct {
+	KeyIDs   []uint32
+	Keys     []K
+	ValueIDs []uint32
+	Values   []V
+
+	PairKeyIDs   []uint32
+	PairValueIDs []uint32
+}
+
+// ExportColumnar writes the RoarIndex to w in a compact columnar format:
+// the value and key dictionaries followed by the key ID and value ID of
+// every pair, grouped by key. Keys and values are encoded with
+// encoding/gob. Use ReadColumnar to read it.
+func (om *RoarIndex[K, V]) ExportColumnar(w io.Writer) error {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(columnarMagic); err != nil {
+		return err
+	}
+
+	header := columnarHeader{Keys: om.backend.KeyCount(), Values: om.backend.ValueCount()}
+	om.backend.RangeBitmaps(func(_ uint32, bm *roaring.Bitmap) bool {
+		header.Pairs += bm.GetCardinality()
+		return true
+	})
+
+	enc := gob.NewEncoder(bw)
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+
+	var err error
+	values := make([]snapshotValue[V], 0, snapshotChunkSize)
+	om.backend.RangeValues(func(value V, valueID uint32) bool {
+		values = append(values, snapshotValue[V]{ID: valueID, Value: value})
+		if len(values) == snapshotChunkSize {
+			err = enc.Encode(values)
+			values = values[:0]
+		}
+		return err == nil
+	})
+	if err == nil && len(values) > 0 {
+		err = enc.Encode(values)
+	}
+	if err != nil {
+		return err
+	}
+
+	keys := make([]columnarKey[K], 0, snapshotChunkSize)
+	om.backend.RangeKeys(func(key K, keyID uint32) bool {
+		keys = append(keys, columnarKey[K]{ID: keyID, Key: key})
+		if len(keys) == snapshotChunkSize {
+			err = enc.Encode(keys)
+			keys = keys[:0]
+		}
+		return err == nil
+	})
+	if err == nil && len(keys) > 0 {
+		err = enc.Encode(keys)
+	}
+	if err != nil {
+		return err
+	}
+
+	var group columnarGroup
+	om.backend.RangeBitmaps(func(keyID uint32, bm *roaring.Bitmap) bool {
+		// run is the index of the run of keyID in group, -1 when the group
+		// has none yet
+		run, previous := -1, uint32(0)
+		it := bm.Iterator()
+		for it.HasNext() {
+			if len(group.ValueDeltas) == columnarGroupSize {
+				if err = enc.Encode(group); err != nil {
+					return false
+				}
+				group, run = columnarGroup{}, -1
+			}
+			if run < 0 {
+				group.KeyIDs = append(group.KeyIDs, keyID)
+				group.RunLengths = append(group.RunLengths, 0)
+				run, previous = len(group.RunLengths)-1, 0
+			}
+
+			valueID := it.Next()
+			group.ValueDeltas = append(group.ValueDeltas, valueID-previous)
+			group.RunLengths[run]++
+			previous = valueID
+		}
+		return true
+	})
+	if err == nil && len(group.ValueDeltas) > 0 {
+		err = enc.Encode(group)
+	}
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// ReadColumnar reads a columnar export written by ExportColumnar.
+func ReadColumnar[K comparable, V comparable](r io.Reader) (*Columnar[K, V], error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(columnarMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, columnarMagic) {
+		return nil, ErrInvalidSnapshot
+	}
+
+	dec := gob.NewDecoder(br)
+	var header columnarHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, errors.Join(ErrInvalidSnapshot, err)
+	}
+
+	// IDs are uint32 and each key holds each value at most once
+	if header.Keys < 0 || header.Values < 0 || uint64(header.Keys) > math.MaxUint32 || uint64(header.Values) > math.MaxUint32 ||
+		header.Pairs > uint64(header.Keys)*uint64(header.Values) {
+		return nil, ErrInvalidSnapshot
+	}
+
+	// Don't trust the counts for more than a chunk of capacity up front,
+	// the slices grow as the entries are actually read
+	keys, values := min(header.Keys, snapshotChunkSize), min(header.Values, snapshotChunkSize)
+	pairs := int(min(header.Pairs, columnarGroupSize))
+	c := &Columnar[K, V]{
+		KeyIDs:       make([]uint32, 0, keys),
+		Keys:         make([]K, 0, keys),
+		ValueIDs:     make([]uint32, 0, values),
+		Values:       make([]V, 0, values),
+		PairKeyIDs:   make([]uint32, 0, pairs),
+		PairValueIDs: make([]uint32, 0, pairs),
+	}
+
+	for len(c.Values) < header.Values {
+		var values []snapshotValue[V]
+		if err := dec.Decode(&values); err != nil {
+			return nil, errors.Join(ErrInvalidSnapshot, err)
+		}
+		for _, entry := range values {
+			c.ValueIDs = append(c.ValueIDs, entry.ID)
+			c.Values = append(c.Values, entry.Value)
+		}
+	}
+
+	for len(c.Keys) < header.Keys {
+		var keys []columnarKey[K]
+		if err := dec.Decode(&keys); err != nil {
+			return nil, errors.Join(ErrInvalidSnapshot, err)
+		}
+		for _, entry := range keys {
+			c.KeyIDs = append(c.KeyIDs, entry.ID)
+			c.Keys = append(c.Keys, entry.Key)
+		}
+	}
+
+	for uint64(len(c.PairValueIDs)) < header.Pairs {
+		var group columnarGroup
+		if err := dec.Decode(&group); err != nil {
+			return nil, errors.Join(ErrInvalidSnapshot, err)
+		}
+		if len(group.KeyIDs) != len(group.RunLengths) {
+			return nil, ErrInvalidSnapshot
+		}
+
+		deltas := group.ValueDeltas
+		for i, keyID := range group.KeyIDs {
+			run := int(group.RunLengths[i])
+			if run > len(deltas) {
+				return nil, ErrInvalidSnapshot
+			}
+			var valueID uint32
+			for _, delta := range deltas[:run] {
+				valueID += delta
+				c.PairKeyIDs = append(c.PairKeyIDs, keyID)
+				c.PairValueIDs = append(c.PairValueIDs, valueID)
+			}
+			deltas = deltas[run:]
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,199 @@
+package roarindex
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newExportIndex() *RoarIndex[string, int] {
+	om := NewRoarIndex[string, int]()
+	om.PushMap("key1", 3)
+	om.PushMap("key1", 1)
+	om.PushMap("key2", 1)
+	om.PushMap("key3", 2)
+	om.PushMap("key1", 2)
+	return om
+}
+
+func TestRoarIndexExportJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newExportIndex().ExportJSON(&buf); err != nil {
+		t.Fatalf("ExportJSON failed: %v", err)
+	}
+
+	expected := `[
+{"key":"key1","values":[3,1,2]},
+{"key":"key2","values":[1]},
+{"key":"key3","values":[2]}
+]
+`
+	if buf.String() != expected {
+		t.Errorf("Expected %q, but got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := NewRoarIndex[string, int]().ExportJSON(&buf); err != nil {
+		t.Fatalf("ExportJSON failed: %v", err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("Expected an empty array, but got %q", buf.String())
+	}
+}
+
+func TestRoarIndexExportJSONLRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newExportIndex().ExportJSONL(&buf); err != nil {
+		t.Fatalf("ExportJSONL failed: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+		t.Errorf("Expected 3 lines, but got %d", lines)
+	}
+
+	om := NewRoarIndex[string, int]()
+	if _, err := om.ImportJSONL(&buf, &ImportOptions[string, int]{ValueField: "values"}); err != nil {
+		t.Fatalf("ImportJSONL failed: %v", err)
+	}
+	if values, _ := om.GetMap("key1"); !reflect.DeepEqual(values, []int{3, 1, 2}) {
+		t.Errorf("Expected [3 1 2], but got %v", values)
+	}
+}
+
+func TestRoarIndexExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newExportIndex().ExportCSV(&buf, &ExportOptions[string, int]{Header: true}); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	expected := "key,value\nkey1,3\nkey1,1\nkey1,2\nkey2,1\nkey3,2\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, but got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := newExportIndex().ExportCSV(&buf, &ExportOptions[string, int]{PerKey: true, Comma: ';'}); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	expected = "key1;3|1|2\nkey2;1\nkey3;2\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, but got %q", expected, buf.String())
+	}
+
+	om := NewRoarIndex[string, int]()
+	if _, err := om.ImportCSV(&buf, &ImportOptions[string, int]{Comma: ';', Delimiter: "|"}); err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if values, _ := om.GetMap("key1"); !reflect.DeepEqual(values, []int{3, 1, 2}) {
+		t.Errorf("Expected [3 1 2], but got %v", values)
+	}
+}
+
+// unencodable fails to encode as JSON.
+type unencodable int
+
+func (unencodable) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("unencodable")
+}
+
+func TestRoarIndexExportCSVFormatError(t *testing.T) {
+	om := NewRoarIndex[string, unencodable]()
+	om.PushMap("key1", 1)
+
+	for _, perKey := range []bool{false, true} {
+		var buf bytes.Buffer
+		err := om.ExportCSV(&buf, &ExportOptions[string, unencodable]{PerKey: perKey})
+		if err == nil || !strings.Contains(err.Error(), "unencodable") {
+			t.Errorf("Expected the encoding error with PerKey %v, but got %v", perKey, err)
+		}
+	}
+}
+
+func TestRoarIndexExportFollowsOrder(t *testing.T) {
+	om := newExportIndex()
+	om.SetOrderFunc(nil, cmp.Compare[int])
+
+	var buf bytes.Buffer
+	if err := om.ExportCSV(&buf, nil); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	expected := "key1,1\nkey1,2\nkey1,3\nkey2,1\nkey3,2\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, but got %q", expected, buf.String())
+	}
+}
+
+func TestRoarIndexExportColumnar(t *testing.T) {
+	om := newExportIndex()
+	for i := 0; i < columnarGroupSize+10; i++ {
+		om.PushMap("big", i)
+	}
+	om.DeleteMap("key3")
+
+	var buf bytes.Buffer
+	if err := om.ExportColumnar(&buf); err != nil {
+		t.Fatalf("ExportColumnar failed: %v", err)
+	}
+
+	c, err := ReadColumnar[string, int](&buf)
+	if err != nil {
+		t.Fatalf("ReadColumnar failed: %v", err)
+	}
+	if len(c.Keys) != 3 || len(c.KeyIDs) != 3 {
+		t.Errorf("Expected 3 keys, but got %d", len(c.Keys))
+	}
+	if len(c.Values) != columnarGroupSize+10 {
+		t.Errorf("Expected %d values, but got %d", columnarGroupSize+10, len(c.Values))
+	}
+	if len(c.PairKeyIDs) != columnarGroupSize+14 || len(c.PairValueIDs) != len(c.PairKeyIDs) {
+		t.Fatalf("Expected %d pairs, but got %d", columnarGroupSize+14, len(c.PairKeyIDs))
+	}
+
+	keys := make(map[uint32]string)
+	for i, id := range c.KeyIDs {
+		keys[id] = c.Keys[i]
+	}
+	values := make(map[uint32]int)
+	for i, id := range c.ValueIDs {
+		values[id] = c.Values[i]
+	}
+	for i := range c.PairKeyIDs {
+		key, value := keys[c.PairKeyIDs[i]], values[c.PairValueIDs[i]]
+		if !om.HasValue(key, value) {
+			t.Fatalf("Unexpected pair %s=%d", key, value)
+		}
+	}
+
+	if _, err := ReadColumnar[string, int](strings.NewReader("garbage")); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot, but got %v", err)
+	}
+}
+
+func TestReadColumnarMalformedHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header columnarHeader
+	}{
+		{"NegativeKeys", columnarHeader{Keys: -1}},
+		{"NegativeValues", columnarHeader{Values: -1}},
+		{"TooManyKeys", columnarHeader{Keys: 1 << 32}},
+		{"TooManyPairs", columnarHeader{Keys: 2, Values: 3, Pairs: 7}},
+		// Plausible counts in a truncated file must not be allocated up front
+		{"Truncated", columnarHeader{Keys: math.MaxUint32, Values: math.MaxUint32, Pairs: 1 << 60}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			buf.Write(columnarMagic)
+			if err := gob.NewEncoder(&buf).Encode(tt.header); err != nil {
+				t.Fatalf("Encoding the header failed: %v", err)
+			}
+			if _, err := ReadColumnar[string, int](&buf); !errors.Is(err, ErrInvalidSnapshot) {
+				t.Errorf("Expected ErrInvalidSnapshot, but got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package roarindex
+
+import (
+	"runtime"
+	"sync"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// parallelFacetThreshold is the number of facets above which FacetCounts
+// spreads the work over multiple goroutines.
+const parallelFacetThreshold = 256
+
+// FacetCounts returns, for each facet key, the number of values it shares
+// with the filter key. Facets that are not in the RoarIndex count 0.
+func (om *RoarIndex[K, V]) FacetCounts(filter K, facets []K) (map[K]uint64, error) {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return om.facetCounts(bm, facets), nil
+}
+
+// FacetCountsBitmap returns, for each facet key, the number of values it
+// shares with filter, a bitmap of value IDs. Facets that are not in the
+// RoarIndex count 0.
+func (om *RoarIndex[K, V]) FacetCountsBitmap(filter *roaring.Bitmap, facets []K) map[K]uint64 {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	return om.facetCounts(filter, facets)
+}
+
+func (om *RoarIndex[K, V]) facetCounts(filter *roaring.Bitmap, facets []K) map[K]uint64 {
+	bitmaps := make([]*roaring.Bitmap, len(facets))
+	for i, facet := range facets {
+		if keyID, keyExists := om.backend.KeyID(facet); keyExists {
+			bitmaps[i], _ = om.backend.Bitmap(keyID)
+		}
+	}
+
+	counts := make([]uint64, len(facets))
+	count := func(from, to int) {
+		for i := from; i < to; i++ {
+			if bitmaps[i] != nil {
+				counts[i] = filter.AndCardinality(bitmaps[i])
+			}
+		}
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if len(facets) < parallelFacetThreshold || workers == 1 {
+		count(0, len(facets))
+	} else {
+		var wg sync.WaitGroup
+		chunk := (len(facets) + workers - 1) / workers
+		for from := 0; from < len(facets); from += chunk {
+			wg.Add(1)
+			go func(from, to int) {
+				defer wg.Done()
+				count(from, to)
+			}(from, min(from+chunk, len(facets)))
+		}
+		wg.Wait()
+	}
+
+	result := make(map[K]uint64, len(facets))
+	for i, facet := range facets {
+		result[facet] = counts[i]
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+package roarindex
+
+import (
+	"reflect"
+	"testing"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+func TestRoarIndexFacetCounts(t *testing.T) {
+	om := NewRoarIndex[string, string]()
+	om.PushMap("color:red", "item1")
+	om.PushMap("color:red", "item2")
+	om.PushMap("color:red", "item3")
+	om.PushMap("size:s", "item1")
+	om.PushMap("size:m", "item2")
+	om.PushMap("size:m", "item3")
+	om.PushMap("size:l", "item4")
+
+	result, err := om.FacetCounts("color:red", []string{"size:s", "size:m", "size:l", "size:xl"})
+	if err != nil {
+		t.Errorf("Expected filter to exist, but it doesn't, error: %s", err.Error())
+	}
+	expected := map[string]uint64{"size:s": 1, "size:m": 2, "size:l": 0, "size:xl": 0}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if _, err := om.FacetCounts("color:blue", []string{"size:s"}); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, but got %v", err)
+	}
+}
+
+func TestRoarIndexFacetCountsBitmapParallel(t *testing.T) {
+	om := NewRoarIndex[int, int]()
+	facets := make([]int, 0, parallelFacetThreshold*4)
+	for i := 0; i < parallelFacetThreshold*4; i++ {
+		for j := 0; j < i%10; j++ {
+			om.PushMap(i, j)
+		}
+		facets = append(facets, i)
+	}
+
+	// Value j was first pushed as j, so value IDs 0..4 are values 0..4
+	filter := roaring.BitmapOf(0, 1, 2, 3, 4)
+	result := om.FacetCountsBitmap(filter, facets)
+	for _, facet := range facets {
+		if expected := uint64(min(facet%10, 5)); result[facet] != expected {
+			t.Fatalf("Expected facet %d to count %d, but got %d", facet, expected, result[facet])
+		}
+	}
+	if len(result) != len(facets) {
+		t.Errorf("Expected %d facets, but got %d", len(facets), len(result))
+	}
+}
@@ -0,0 +1,298 @@
+package roarindex
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrMalformedInput is returned by ImportCSV and ImportJSONL for rows that
+// cannot be imported, wrapped with the line number of the row.
+var ErrMalformedInput = errors.New("malformed input")
+
+// ImportOptions configures ImportCSV and ImportJSONL. The zero value is ready
+// to use.
+type ImportOptions[K comparable, V comparable] struct {
+	// KeyColumn and ValueColumn are the 1-based CSV columns holding the key
+	// and the values, 1 and 2 when 0.
+	KeyColumn   int
+	ValueColumn int
+	// Comma is the CSV field separator, ',' when 0.
+	Comma rune
+	// Header skips the first CSV row.
+	Header bool
+	// KeyField and ValueField are the JSON Lines fields holding the key and
+	// the values, "key" and "value" when empty. A JSON array in the value
+	// field holds multiple values.
+	KeyField   string
+	ValueField string
+	// Delimiter splits the value column or field into multiple values when
+	// not empty. Surrounding spaces are trimmed and empty values skipped.
+	Delimiter string
+	// ParseKey and ParseValue convert the text of a key or value. When nil,
+	// strings are used as is and other types are decoded as JSON, so
+	// numbers and booleans work without a parse function. JSON objects and
+	// arrays are only imported through a parse function, which receives
+	// their JSON text.
+	ParseKey   func(string) (K, error)
+	ParseValue func(string) (V, error)
+	// BatchSize is the number of pairs pushed per lock acquisition, 4096
+	// when 0.
+	BatchSize int
+}
+
+// ImportCSV pushes the key-value pairs read from CSV rows in r and returns
+// the number of pairs pushed. Rows before a malformed row are imported.
+func (om *RoarIndex[K, V]) ImportCSV(r io.Reader, opts *ImportOptions[K, V]) (int, error) {
+	im := newImporter(om, opts)
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	if opts != nil && opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	keyColumn, valueColumn := 0, 1
+	if opts != nil && opts.KeyColumn > 0 {
+		keyColumn = opts.KeyColumn - 1
+	}
+	if opts != nil && opts.ValueColumn > 0 {
+		valueColumn = opts.ValueColumn - 1
+	}
+
+	for row := 0; ; row++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			im.flush()
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return im.pushed, fmt.Errorf("%w: line %d: %w", ErrMalformedInput, parseErr.Line, parseErr.Err)
+			}
+			return im.pushed, err
+		}
+		if row == 0 && opts != nil && opts.Header {
+			continue
+		}
+
+		line, _ := cr.FieldPos(0)
+		if keyColumn >= len(record) || valueColumn >= len(record) {
+			im.flush()
+			return im.pushed, fmt.Errorf("%w: line %d: %d columns, need %d", ErrMalformedInput, line, len(record), max(keyColumn, valueColumn)+1)
+		}
+		if err := im.add(record[keyColumn], []string{record[valueColumn]}); err != nil {
+			im.flush()
+			return im.pushed, fmt.Errorf("%w: line %d: %w", ErrMalformedInput, line, err)
+		}
+	}
+
+	im.flush()
+	return im.pushed, nil
+}
+
+// ImportJSONL pushes the key-value pairs read from JSON objects in r, one
+// per line, and returns the number of pairs pushed. Blank lines are skipped
+// and lines before a malformed line are imported.
+func (om *RoarIndex[K, V]) ImportJSONL(r io.Reader, opts *ImportOptions[K, V]) (int, error) {
+	im := newImporter(om, opts)
+
+	keyField, valueField := "key", "value"
+	var parseKey, parseValue bool
+	if opts != nil && opts.KeyField != "" {
+		keyField = opts.KeyField
+	}
+	if opts != nil && opts.ValueField != "" {
+		valueField = opts.ValueField
+	}
+	if opts != nil {
+		parseKey, parseValue = opts.ParseKey != nil, opts.ParseValue != nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		key, values, err := jsonlFields(scanner.Bytes(), keyField, valueField, parseKey, parseValue)
+		if err == nil {
+			err = im.add(key, values)
+		}
+		if err != nil {
+			im.flush()
+			return im.pushed, fmt.Errorf("%w: line %d: %w", ErrMalformedInput, line, err)
+		}
+	}
+
+	im.flush()
+	return im.pushed, scanner.Err()
+}
+
+// jsonlFields returns the text of the key field and of every value in the
+// value field of a JSON object. parseKey and parseValue allow objects and
+// arrays as keys and values, for a parse function to decode.
+func jsonlFields(data []byte, keyField, valueField string, parseKey, parseValue bool) (string, []string, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return "", nil, err
+	}
+
+	rawKey, ok := object[keyField]
+	if !ok {
+		return "", nil, fmt.Errorf("missing field %q", keyField)
+	}
+	key, err := jsonText(rawKey, parseKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("field %q: %w", keyField, err)
+	}
+
+	rawValue, ok := object[valueField]
+	if !ok {
+		return "", nil, fmt.Errorf("missing field %q", valueField)
+	}
+	var rawValues []json.RawMessage
+	if err := json.Unmarshal(rawValue, &rawValues); err != nil || rawValues == nil {
+		rawValues = []json.RawMessage{rawValue}
+	}
+
+	values := make([]string, len(rawValues))
+	for i, raw := range rawValues {
+		if values[i], err = jsonText(raw, parseValue); err != nil {
+			return "", nil, fmt.Errorf("field %q: %w", valueField, err)
+		}
+	}
+	return key, values, nil
+}
+
+// jsonText returns the contents of a JSON string, or the JSON text of a
+// number or boolean, or of an object or array when structured is set. null
+// is rejected rather than read as an empty string.
+func jsonText(raw json.RawMessage, structured bool) (string, error) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", err
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case float64, bool:
+		return string(raw), nil
+	case map[string]any, []any:
+		if structured {
+			return string(raw), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported JSON value %s", raw)
+}
+
+// importer parses pairs and pushes them to a RoarIndex in batches, so the
+// lock is taken once per batch instead of once per pair.
+type importer[K comparable, V comparable] struct {
+	om         *RoarIndex[K, V]
+	delimiter  string
+	parseKey   func(string) (K, error)
+	parseValue func(string) (V, error)
+	batchSize  int
+
+	keys   []K
+	values []V
+	pushed int
+}
+
+func newImporter[K comparable, V comparable](om *RoarIndex[K, V], opts *ImportOptions[K, V]) *importer[K, V] {
+	if opts == nil {
+		opts = &ImportOptions[K, V]{}
+	}
+
+	im := &importer[K, V]{
+		om:         om,
+		delimiter:  opts.Delimiter,
+		parseKey:   opts.ParseKey,
+		parseValue: opts.ParseValue,
+		batchSize:  opts.BatchSize,
+	}
+	if im.parseKey == nil {
+		im.parseKey = parseText[K]
+	}
+	if im.parseValue == nil {
+		im.parseValue = parseText[V]
+	}
+	if im.batchSize <= 0 {
+		im.batchSize = 4096
+	}
+	return im
+}
+
+// add parses a key and its values, splitting them on the delimiter, and
+// queues the pairs. Nothing is queued when any of them fails to parse.
+func (im *importer[K, V]) add(rawKey string, rawValues []string) error {
+	key, err := im.parseKey(rawKey)
+	if err != nil {
+		return fmt.Errorf("key %q: %w", rawKey, err)
+	}
+
+	queued := len(im.values)
+	for _, raw := range rawValues {
+		parts := []string{raw}
+		if im.delimiter != "" {
+			parts = strings.Split(raw, im.delimiter)
+		}
+
+		for _, part := range parts {
+			if im.delimiter != "" {
+				if part = strings.TrimSpace(part); part == "" {
+					continue
+				}
+			}
+
+			value, err := im.parseValue(part)
+			if err != nil {
+				im.keys, im.values = im.keys[:queued], im.values[:queued]
+				return fmt.Errorf("value %q: %w", part, err)
+			}
+			im.keys = append(im.keys, key)
+			im.values = append(im.values, value)
+		}
+	}
+
+	if len(im.values) >= im.batchSize {
+		im.flush()
+	}
+	return nil
+}
+
+// flush pushes the queued pairs under a single lock.
+func (im *importer[K, V]) flush() {
+	if len(im.values) == 0 {
+		return
+	}
+
+	im.om.mtx.Lock()
+	for i, value := range im.values {
+		im.om.pushLocked(im.keys[i], value)
+	}
+	im.om.mtx.Unlock()
+
+	im.pushed += len(im.values)
+	im.keys, im.values = im.keys[:0], im.values[:0]
+}
+
+// parseText is the default parse function: strings are used as is, other
+// types are decoded as JSON.
+func parseText[T any](s string) (T, error) {
+	var t T
+	if p, ok := any(&t).(*string); ok {
+		*p = s
+		return t, nil
+	}
+	err := json.Unmarshal([]byte(s), &t)
+	return t, err
+}
@@ -0,0 +1,189 @@
+package roarindex
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func sortedValues[K comparable, V comparable](t *testing.T, om *RoarIndex[K, V], key K, less func(a, b V) int) []V {
+	t.Helper()
+	values, err := om.GetMap(key)
+	if err != nil {
+		t.Fatalf("GetMap(%v) failed: %v", key, err)
+	}
+	slices.SortFunc(values, less)
+	return values
+}
+
+func TestRoarIndexImportCSV(t *testing.T) {
+	om := NewRoarIndex[string, string]()
+	input := "key1,value1\nkey1,value2\nkey2,value2\n"
+
+	n, err := om.ImportCSV(strings.NewReader(input), &ImportOptions[string, string]{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Expected 3 pairs, but got %d", n)
+	}
+	if values := sortedValues(t, om, "key1", strings.Compare); !reflect.DeepEqual(values, []string{"value1", "value2"}) {
+		t.Errorf("Expected [value1 value2], but got %v", values)
+	}
+	if !om.HasValue("key2", "value2") {
+		t.Errorf("Expected key2 to hold value2")
+	}
+}
+
+func TestRoarIndexImportCSVOptions(t *testing.T) {
+	om := NewRoarIndex[int, int]()
+	input := "name;id;tags\nalice;1;10|20\nbob;2; 30 ||\n"
+
+	n, err := om.ImportCSV(strings.NewReader(input), &ImportOptions[int, int]{
+		KeyColumn:   2,
+		ValueColumn: 3,
+		Comma:       ';',
+		Header:      true,
+		Delimiter:   "|",
+	})
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Expected 3 pairs, but got %d", n)
+	}
+	if values := sortedValues(t, om, 1, func(a, b int) int { return a - b }); !reflect.DeepEqual(values, []int{10, 20}) {
+		t.Errorf("Expected [10 20], but got %v", values)
+	}
+	if values := sortedValues(t, om, 2, func(a, b int) int { return a - b }); !reflect.DeepEqual(values, []int{30}) {
+		t.Errorf("Expected [30], but got %v", values)
+	}
+}
+
+func TestRoarIndexImportParseFuncs(t *testing.T) {
+	om := NewRoarIndex[string, uint64]()
+	input := "key1,ff\nkey1,0a\n"
+
+	_, err := om.ImportCSV(strings.NewReader(input), &ImportOptions[string, uint64]{
+		ParseValue: func(s string) (uint64, error) { return strconv.ParseUint(s, 16, 64) },
+	})
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if values := sortedValues(t, om, "key1", func(a, b uint64) int { return int(a) - int(b) }); !reflect.DeepEqual(values, []uint64{10, 255}) {
+		t.Errorf("Expected [10 255], but got %v", values)
+	}
+}
+
+func TestRoarIndexImportCSVMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  string
+	}{
+		{"missing column", "key1,1\nkey2\n", "line 2:"},
+		{"parse error", "key1,1\nkey2,2\nkey3,x\n", "line 3:"},
+		{"bad quote", "key1,1\n\"key2,2\n", "line 2:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			om := NewRoarIndex[string, int]()
+			n, err := om.ImportCSV(strings.NewReader(tt.input), nil)
+			if !errors.Is(err, ErrMalformedInput) {
+				t.Fatalf("Expected ErrMalformedInput, but got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.line) {
+				t.Errorf("Expected %q in %q", tt.line, err)
+			}
+			if !om.HasValue("key1", 1) || n < 1 {
+				t.Errorf("Expected the rows before the malformed row to be imported")
+			}
+		})
+	}
+}
+
+func TestRoarIndexImportJSONL(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	input := `{"user": "alice", "items": [1, 2]}` + "\n\n" +
+		`{"user": "bob", "items": 3, "other": true}` + "\n" +
+		`{"user": "carol", "items": "4, 5"}` + "\n"
+
+	n, err := om.ImportJSONL(strings.NewReader(input), &ImportOptions[string, int]{
+		KeyField:   "user",
+		ValueField: "items",
+		Delimiter:  ",",
+	})
+	if err != nil {
+		t.Fatalf("ImportJSONL failed: %v", err)
+	}
+	if n != 5 {
+		t.Errorf("Expected 5 pairs, but got %d", n)
+	}
+	if values := sortedValues(t, om, "alice", func(a, b int) int { return a - b }); !reflect.DeepEqual(values, []int{1, 2}) {
+		t.Errorf("Expected [1 2], but got %v", values)
+	}
+	if !om.HasValue("bob", 3) {
+		t.Errorf("Expected bob to hold 3")
+	}
+	if values := sortedValues(t, om, "carol", func(a, b int) int { return a - b }); !reflect.DeepEqual(values, []int{4, 5}) {
+		t.Errorf("Expected [4 5], but got %v", values)
+	}
+}
+
+func TestRoarIndexImportJSONLStructs(t *testing.T) {
+	type item struct {
+		SKU string
+		Qty int
+	}
+	om := NewRoarIndex[string, item]()
+	input := `{"key": "order1", "value": [{"SKU": "a", "Qty": 1}, {"SKU": "b", "Qty": 2}]}` + "\n"
+
+	n, err := om.ImportJSONL(strings.NewReader(input), &ImportOptions[string, item]{
+		ParseValue: func(s string) (item, error) {
+			var it item
+			err := json.Unmarshal([]byte(s), &it)
+			return it, err
+		},
+	})
+	if err != nil {
+		t.Fatalf("ImportJSONL failed: %v", err)
+	}
+	if n != 2 || !om.HasValue("order1", item{"a", 1}) || !om.HasValue("order1", item{"b", 2}) {
+		t.Errorf("Expected order1 to hold both items, but got %d pairs", n)
+	}
+}
+
+func TestRoarIndexImportJSONLMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  string
+	}{
+		{"invalid json", `{"key": "key1", "value": "value1"}` + "\n\n{oops\n", "line 3:"},
+		{"missing field", `{"key": "key1", "value": "value1"}` + "\n" + `{"key": "key2"}`, "line 2: missing field \"value\""},
+		{"nested object", `{"key": "key1", "value": "value1"}` + "\n" + `{"key": "key2", "value": {}}`, "line 2:"},
+		{"null value", `{"key": "key1", "value": "value1"}` + "\n" + `{"key": "key2", "value": null}`, "line 2: field \"value\": unsupported JSON value null"},
+		{"null key", `{"key": "key1", "value": "value1"}` + "\n" + `{"key": null, "value": "value2"}`, "line 2: field \"key\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			om := NewRoarIndex[string, string]()
+			_, err := om.ImportJSONL(strings.NewReader(tt.input), nil)
+			if !errors.Is(err, ErrMalformedInput) {
+				t.Fatalf("Expected ErrMalformedInput, but got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.line) {
+				t.Errorf("Expected %q in %q", tt.line, err)
+			}
+			if !om.HasValue("key1", "value1") {
+				t.Errorf("Expected the lines before the malformed line to be imported")
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package roarindex
+
+// Index is the method set shared by RoarIndex and its remote clients, so
+// callers can swap a local index for a remote one.
+type Index[K comparable, V comparable] interface {
+	PushMap(key K, value V)
+	GetMap(key K) ([]V, error)
+	HasValue(key K, value V) bool
+	DeleteMap(key K)
+	Keys() []K
+	Values() []V
+	Count() int
+}
+
+var _ Index[string, string] = (*RoarIndex[string, string])(nil)
@@ -0,0 +1,111 @@
+package roarindex
+
+import (
+	"errors"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// ErrUnknownStrategy is returned for an unsupported MergeStrategy.
+var ErrUnknownStrategy = errors.New("unknown merge strategy")
+
+// MergeStrategy decides the values of keys present in both indexes of a
+// Merge. Keys only present in the other index are always added.
+type MergeStrategy int
+
+const (
+	// MergeUnion keeps the values of both indexes.
+	MergeUnion MergeStrategy = iota
+	// MergeReplace keeps the values of the other index.
+	MergeReplace
+	// MergeIntersect keeps the values present in both indexes.
+	MergeIntersect
+)
+
+// Merge adds the keys and values of other to the RoarIndex, combining the
+// values of keys present in both according to strategy. The value IDs of
+// other are translated into the ID space of the RoarIndex. other is not
+// modified and both indexes may be merged into each other concurrently.
+func (om *RoarIndex[K, V]) Merge(other *RoarIndex[K, V], strategy MergeStrategy) error {
+	if strategy < MergeUnion || strategy > MergeIntersect {
+		return ErrUnknownStrategy
+	}
+	if other == om {
+		// Every strategy leaves an index merged with itself unchanged
+		return nil
+	}
+
+	// Always lock the index with the lower id first, so two indexes merging
+	// into each other cannot deadlock
+	if om.id < other.id {
+		om.mtx.Lock()
+		other.mtx.RLock()
+	} else {
+		other.mtx.RLock()
+		om.mtx.Lock()
+	}
+	defer om.mtx.Unlock()
+	defer other.mtx.RUnlock()
+
+	// Value IDs of other translated so far
+	valueIDs := make(map[uint32]uint32)
+	translated := make([]uint32, 0, 64)
+
+	other.backend.RangeKeys(func(key K, otherKeyID uint32) bool {
+		translated = translated[:0]
+		if otherBm, exists := other.backend.Bitmap(otherKeyID); exists {
+			it := otherBm.Iterator()
+			for it.HasNext() {
+				otherValueID := it.Next()
+				valueID, seen := valueIDs[otherValueID]
+				if !seen {
+					value, _ := other.backend.Value(otherValueID)
+					valueID = om.valueIDOrAssign(value)
+					valueIDs[otherValueID] = valueID
+				}
+				translated = append(translated, valueID)
+			}
+		}
+		incoming := roaring.BitmapOf(translated...)
+
+		keyID := om.keyIDOrAssign(key)
+		current, exists := om.backend.Bitmap(keyID)
+		if !exists {
+			current = roaring.NewBitmap()
+		}
+
+		var merged *roaring.Bitmap
+		switch {
+		case !exists || strategy == MergeReplace:
+			merged = incoming
+		case strategy == MergeUnion:
+			merged = roaring.Or(current, incoming)
+		case strategy == MergeIntersect:
+			merged = roaring.And(current, incoming)
+		}
+
+		if exists && merged.Equals(current) {
+			return true
+		}
+		if om.reverse != nil {
+			om.reverseRemove(keyID, roaring.AndNot(current, merged))
+			it := roaring.AndNot(merged, current).Iterator()
+			for it.HasNext() {
+				om.reverseAdd(keyID, it.Next())
+			}
+		}
+		if om.sequences != nil {
+			// Merged values are taken to be pushed in value ID order
+			om.sequenceRemove(keyID, roaring.AndNot(current, merged))
+			it := roaring.AndNot(merged, current).Iterator()
+			for it.HasNext() {
+				om.sequenceAdd(keyID, it.Next())
+			}
+		}
+		om.backend.PutBitmap(keyID, merged)
+		om.dirty.Add(keyID)
+		return true
+	})
+
+	return nil
+}
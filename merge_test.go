@@ -0,0 +1,147 @@
+package roarindex
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newMergeIndexes() (*RoarIndex[string, string], *RoarIndex[string, string]) {
+	a := NewRoarIndex[string, string]()
+	a.PushMap("shared", "value1")
+	a.PushMap("shared", "value2")
+	a.PushMap("onlyA", "value1")
+
+	// Push in another order so both indexes assign different IDs
+	b := NewRoarIndex[string, string]()
+	b.PushMap("onlyB", "value4")
+	b.PushMap("onlyB", "value3")
+	b.PushMap("shared", "value3")
+	b.PushMap("shared", "value2")
+	return a, b
+}
+
+func sortedGetMap(t *testing.T, om *RoarIndex[string, string], key string) []string {
+	t.Helper()
+	values, err := om.GetMap(key)
+	if err != nil {
+		t.Fatalf("GetMap(%q) failed: %v", key, err)
+	}
+	slices.Sort(values)
+	return values
+}
+
+func TestRoarIndexMerge(t *testing.T) {
+	tests := []struct {
+		strategy MergeStrategy
+		shared   []string
+	}{
+		{MergeUnion, []string{"value1", "value2", "value3"}},
+		{MergeReplace, []string{"value2", "value3"}},
+		{MergeIntersect, []string{"value2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.strategy), func(t *testing.T) {
+			a, b := newMergeIndexes()
+			if err := a.Merge(b, tt.strategy); err != nil {
+				t.Fatalf("Merge failed: %v", err)
+			}
+
+			if values := sortedGetMap(t, a, "shared"); !reflect.DeepEqual(values, tt.shared) {
+				t.Errorf("Expected %v, but got %v", tt.shared, values)
+			}
+			if values := sortedGetMap(t, a, "onlyA"); !reflect.DeepEqual(values, []string{"value1"}) {
+				t.Errorf("Expected onlyA to be unchanged, but got %v", values)
+			}
+			if values := sortedGetMap(t, a, "onlyB"); !reflect.DeepEqual(values, []string{"value3", "value4"}) {
+				t.Errorf("Expected onlyB to be added, but got %v", values)
+			}
+			if a.Count() != 3 {
+				t.Errorf("Expected 3 keys, but got %d", a.Count())
+			}
+			if values := sortedGetMap(t, b, "shared"); !reflect.DeepEqual(values, []string{"value2", "value3"}) {
+				t.Errorf("Expected the other index to be unchanged, but got %v", values)
+			}
+
+			// New pushes must not collide with the IDs assigned by the merge
+			a.PushMap("new", "value5")
+			if values := sortedGetMap(t, a, "new"); !reflect.DeepEqual(values, []string{"value5"}) {
+				t.Errorf("Expected [value5], but got %v", values)
+			}
+		})
+	}
+}
+
+func TestRoarIndexMergeEdgeCases(t *testing.T) {
+	a, b := newMergeIndexes()
+	if err := a.Merge(b, MergeStrategy(42)); err != ErrUnknownStrategy {
+		t.Errorf("Expected ErrUnknownStrategy, but got %v", err)
+	}
+	if err := a.Merge(a, MergeIntersect); err != nil {
+		t.Errorf("Merging an index into itself failed: %v", err)
+	}
+	if values := sortedGetMap(t, a, "shared"); !reflect.DeepEqual(values, []string{"value1", "value2"}) {
+		t.Errorf("Expected merging into itself to be a no-op, but got %v", values)
+	}
+}
+
+func TestRoarIndexMergeReverseIndex(t *testing.T) {
+	a, b := newMergeIndexes()
+	a.EnableReverseIndex()
+	if err := a.Merge(b, MergeReplace); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	// onlyA shares value1 with shared only before the merge
+	similar, err := a.MostSimilar("onlyA", 10, Jaccard)
+	if err != nil {
+		t.Fatalf("MostSimilar failed: %v", err)
+	}
+	if len(similar) != 0 {
+		t.Errorf("Expected no similar keys, but got %v", similar)
+	}
+
+	similar, err = a.MostSimilar("onlyB", 10, Jaccard)
+	if err != nil {
+		t.Fatalf("MostSimilar failed: %v", err)
+	}
+	if len(similar) != 1 || similar[0].Key != "shared" {
+		t.Errorf("Expected shared to be similar to onlyB, but got %v", similar)
+	}
+}
+
+func TestRoarIndexMergeConcurrent(t *testing.T) {
+	a, b := newMergeIndexes()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				a.Merge(b, MergeUnion)
+			}()
+			go func() {
+				defer wg.Done()
+				b.Merge(a, MergeUnion)
+			}()
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Merging two indexes into each other deadlocked")
+	}
+
+	if values := sortedGetMap(t, b, "shared"); !reflect.DeepEqual(values, []string{"value1", "value2", "value3"}) {
+		t.Errorf("Expected [value1 value2 value3], but got %v", values)
+	}
+}
@@ -0,0 +1,99 @@
+package roarindex
+
+import (
+	"time"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// Optimize run-length encodes every bitmap in the RoarIndex where that
+// reduces its size. Keys holding contiguous ranges of value IDs benefit the
+// most.
+func (om *RoarIndex[K, V]) Optimize() {
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	keyIDs := roaring.NewBitmap()
+	om.backend.RangeKeys(func(_ K, keyID uint32) bool {
+		keyIDs.Add(keyID)
+		return true
+	})
+	om.optimizeLocked(keyIDs)
+	om.dirty.Clear()
+}
+
+// OptimizeDirty run-length encodes only the bitmaps that changed since the
+// last optimize pass and returns the number of bitmaps it visited.
+func (om *RoarIndex[K, V]) OptimizeDirty() int {
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	count := om.optimizeLocked(om.dirty)
+	om.dirty.Clear()
+	return count
+}
+
+// optimizeLocked run-length encodes the bitmaps of the given key IDs and
+// returns the number of bitmaps it visited. The caller must hold the write
+// lock.
+func (om *RoarIndex[K, V]) optimizeLocked(keyIDs *roaring.Bitmap) int {
+	count := 0
+	it := keyIDs.Iterator()
+	for it.HasNext() {
+		keyID := it.Next()
+		if bm, exists := om.backend.Bitmap(keyID); exists {
+			bm.RunOptimize()
+			om.backend.PutBitmap(keyID, bm)
+			count++
+		}
+	}
+	return count
+}
+
+// StartOptimizer starts a background goroutine that calls OptimizeDirty
+// every interval. A running optimizer is stopped and replaced.
+func (om *RoarIndex[K, V]) StartOptimizer(interval time.Duration) {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	om.mtx.Lock()
+	prevStop, prevDone := om.optimizerStop, om.optimizerDone
+	om.optimizerStop, om.optimizerDone = stop, done
+	om.mtx.Unlock()
+
+	if prevStop != nil {
+		close(prevStop)
+		<-prevDone
+	}
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				om.OptimizeDirty()
+			}
+		}
+	}()
+}
+
+// StopOptimizer stops the background optimizer, if any, and waits for it
+// to exit.
+func (om *RoarIndex[K, V]) StopOptimizer() {
+	om.mtx.Lock()
+	stop, done := om.optimizerStop, om.optimizerDone
+	om.optimizerStop, om.optimizerDone = nil, nil
+	om.mtx.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
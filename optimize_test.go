@@ -0,0 +1,93 @@
+package roarindex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoarIndexOptimize(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	for i := 0; i < 10000; i++ {
+		om.PushMap("map1", i)
+	}
+
+	bm, _ := om.bitmapOf("map1")
+	before := bm.GetSizeInBytes()
+	om.Optimize()
+	after := bm.GetSizeInBytes()
+
+	if after >= before {
+		t.Errorf("Expected optimized bitmap to shrink, before %d after %d", before, after)
+	}
+	if !om.dirty.IsEmpty() {
+		t.Errorf("Expected no dirty keys after Optimize, got %d", om.dirty.GetCardinality())
+	}
+
+	// Check if the values are still intact
+	result, err := om.GetMap("map1")
+	if err != nil {
+		t.Errorf("Expected map1 to exist, but it doesn't, error: %s", err.Error())
+	}
+	if len(result) != 10000 {
+		t.Errorf("Expected 10000 values, but got %d", len(result))
+	}
+	if !om.HasValue("map1", 9999) {
+		t.Errorf("Expected map1 to contain 9999")
+	}
+}
+
+func TestRoarIndexOptimizeDirty(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	om.PushMap("map1", 1)
+	om.PushMap("map2", 2)
+
+	if n := om.OptimizeDirty(); n != 2 {
+		t.Errorf("Expected 2 dirty bitmaps, got %d", n)
+	}
+	if n := om.OptimizeDirty(); n != 0 {
+		t.Errorf("Expected 0 dirty bitmaps, got %d", n)
+	}
+
+	// Pushing a duplicate does not change the bitmap
+	om.PushMap("map1", 1)
+	om.PushMap("map2", 3)
+	if n := om.OptimizeDirty(); n != 1 {
+		t.Errorf("Expected 1 dirty bitmap, got %d", n)
+	}
+
+	// Deleted keys are no longer dirty
+	om.PushMap("map1", 4)
+	om.DeleteMap("map1")
+	if n := om.OptimizeDirty(); n != 0 {
+		t.Errorf("Expected 0 dirty bitmaps after delete, got %d", n)
+	}
+}
+
+func TestRoarIndexBackgroundOptimizer(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	om.StartOptimizer(time.Millisecond)
+	defer om.StopOptimizer()
+
+	for i := 0; i < 1000; i++ {
+		om.PushMap("map1", i)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		om.mtx.RLock()
+		clean := om.dirty.IsEmpty()
+		om.mtx.RUnlock()
+		if clean {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected background optimizer to clear dirty keys")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Restarting replaces the running optimizer
+	om.StartOptimizer(time.Millisecond)
+	om.StopOptimizer()
+	om.StopOptimizer()
+}
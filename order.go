@@ -0,0 +1,97 @@
+package roarindex
+
+import (
+	"context"
+	"slices"
+)
+
+// Order is the order in which Keys, Values and GetMap return their results.
+type Order int
+
+const (
+	// OrderNone leaves the order of Keys and Values to the backend, which
+	// for the MemoryBackend changes between runs. GetMap returns values in
+	// the order they were first pushed to any key. This is the default.
+	OrderNone Order = iota
+	// OrderInsertion returns keys and values in the order they were first
+	// pushed, by their internal IDs.
+	OrderInsertion
+)
+
+// SetOrder sets the order in which Keys, Values and GetMap return their
+// results, dropping any comparators set by SetOrderFunc.
+func (om *RoarIndex[K, V]) SetOrder(order Order) {
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	om.order = order
+	om.compareKeys = nil
+	om.compareValues = nil
+}
+
+// SetOrderFunc makes Keys return keys sorted by compareKeys, and Values
+// and GetMap return values sorted by compareValues. The values of each key
+// in GetMaps, GetMapWhere, the RangeIndex queries and the exports follow
+// GetMap. Either may be nil to keep insertion order. Keys or values that
+// compare equal keep their insertion order, so the result is
+// deterministic.
+func (om *RoarIndex[K, V]) SetOrderFunc(compareKeys func(a, b K) int, compareValues func(a, b V) int) {
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	om.order = OrderInsertion
+	om.compareKeys = compareKeys
+	om.compareValues = compareValues
+}
+
+// keysInOrderCtx returns all keys in insertion order, sorted by
+// compareKeys when set. The caller must hold the lock.
+func (om *RoarIndex[K, V]) keysInOrderCtx(ctx context.Context) ([]K, error) {
+	var err error
+	keys := make([]K, 0, om.backend.KeyCount())
+	om.keyIDsLocked().Iterate(func(keyID uint32) bool {
+		if len(keys)%ctxCheckInterval == 0 {
+			if err = ctx.Err(); err != nil {
+				return false
+			}
+		}
+		if key, exists := om.backend.Key(keyID); exists {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if om.compareKeys != nil {
+		slices.SortStableFunc(keys, om.compareKeys)
+	}
+	return keys, nil
+}
+
+// valuesInOrderCtx returns all values in insertion order, sorted by
+// compareValues when set. Values are never removed, so their IDs are dense.
+// The caller must hold the lock.
+func (om *RoarIndex[K, V]) valuesInOrderCtx(ctx context.Context) ([]V, error) {
+	values := make([]V, 0, om.backend.ValueCount())
+	for valueID := uint32(0); valueID < om.nextValueID; valueID++ {
+		if valueID%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if value, exists := om.backend.Value(valueID); exists {
+			values = append(values, value)
+		}
+	}
+	om.sortValues(values)
+	return values, nil
+}
+
+// sortValues sorts values by compareValues when set. The caller must hold
+// the lock.
+func (om *RoarIndex[K, V]) sortValues(values []V) {
+	if om.compareValues != nil {
+		slices.SortStableFunc(values, om.compareValues)
+	}
+}
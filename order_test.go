@@ -0,0 +1,71 @@
+package roarindex
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRoarIndexOrderInsertion(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	om.SetOrder(OrderInsertion)
+	for i := 9; i >= 0; i-- {
+		om.PushMap(fmt.Sprintf("key%d", i), i*10)
+	}
+	om.DeleteMap("key5")
+
+	// Repeat to catch map iteration order leaking through
+	for i := 0; i < 10; i++ {
+		if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"key9", "key8", "key7", "key6", "key4", "key3", "key2", "key1", "key0"}) {
+			t.Fatalf("Expected keys in insertion order, but got %v", keys)
+		}
+		if values := om.Values(); !reflect.DeepEqual(values, []int{90, 80, 70, 60, 50, 40, 30, 20, 10, 0}) {
+			t.Fatalf("Expected values in insertion order, but got %v", values)
+		}
+	}
+}
+
+func TestRoarIndexOrderFunc(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	om.PushMap("b", 3)
+	om.PushMap("a", 1)
+	om.PushMap("b", 2)
+	om.SetOrderFunc(cmp.Compare[string], cmp.Compare[int])
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"a", "b"}) {
+		t.Errorf("Expected [a b], but got %v", keys)
+	}
+	if values := om.Values(); !reflect.DeepEqual(values, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], but got %v", values)
+	}
+	if values, err := om.GetMap("b"); err != nil || !reflect.DeepEqual(values, []int{2, 3}) {
+		t.Errorf("Expected [2 3], but got %v and %v", values, err)
+	}
+	if values, err := om.AppendMap([]int{9}, "b"); err != nil || !reflect.DeepEqual(values, []int{9, 2, 3}) {
+		t.Errorf("Expected [9 2 3], but got %v and %v", values, err)
+	}
+	if result, _ := om.GetMaps([]string{"b", "a"}); !reflect.DeepEqual(result["b"], []int{2, 3}) {
+		t.Errorf("Expected [2 3], but got %v", result["b"])
+	}
+
+	// Back to the default keeps GetMap in value ID order
+	om.SetOrder(OrderNone)
+	if values, err := om.GetMap("b"); err != nil || !reflect.DeepEqual(values, []int{3, 2}) {
+		t.Errorf("Expected [3 2], but got %v and %v", values, err)
+	}
+}
+
+func TestRoarIndexOrderCtx(t *testing.T) {
+	om := newContextIndex()
+	om.SetOrder(OrderInsertion)
+
+	if _, err := om.KeysCtx(&countdownContext{Context: context.Background(), n: 3}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled for KeysCtx, but got %v", err)
+	}
+	if _, err := om.ValuesCtx(&countdownContext{Context: context.Background(), n: 3}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled for ValuesCtx, but got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package roarindex
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// keyIndex is a secondary index on keys, such as OrderedKeys, that the
+// RoarIndex keeps up to date.
+type keyIndex[K comparable] interface {
+	addKey(key K)
+	removeKey(key K)
+}
+
+// OrderedKeys keeps the keys of a RoarIndex sorted, for scans over a range
+// or a prefix of keys.
+type OrderedKeys[K comparable, V comparable] struct {
+	om      *RoarIndex[K, V]
+	compare func(a, b K) int
+
+	// Guards the sorting of pending into keys by concurrent readers, which
+	// only hold the read lock of om
+	mtx sync.Mutex
+
+	// Sorted keys, and keys added since the last sort
+	keys    []K
+	pending []K
+}
+
+// EnableOrderedKeys starts keeping the keys of om in their natural order.
+// Existing keys are indexed right away.
+func EnableOrderedKeys[K cmp.Ordered, V comparable](om *RoarIndex[K, V]) *OrderedKeys[K, V] {
+	return EnableOrderedKeysFunc(om, cmp.Compare[K])
+}
+
+// EnableOrderedKeysFunc starts keeping the keys of om in the order of
+// compare, which returns a negative number when a sorts before b, a
+// positive number when a sorts after b and zero otherwise. Existing keys are
+// indexed right away.
+func EnableOrderedKeysFunc[K comparable, V comparable](om *RoarIndex[K, V], compare func(a, b K) int) *OrderedKeys[K, V] {
+	ok := &OrderedKeys[K, V]{
+		om:      om,
+		compare: compare,
+	}
+
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	om.backend.RangeKeys(func(key K, _ uint32) bool {
+		ok.addKey(key)
+		return true
+	})
+	om.keyIndexes = append(om.keyIndexes, ok)
+	return ok
+}
+
+// addKey indexes a new key. The caller must hold the write lock of om.
+// Sorting is deferred to the next read, so bulk loads don't pay for an
+// insertion per key.
+func (ok *OrderedKeys[K, V]) addKey(key K) {
+	ok.pending = append(ok.pending, key)
+}
+
+// removeKey drops a deleted key. The caller must hold the write lock of om.
+func (ok *OrderedKeys[K, V]) removeKey(key K) {
+	if i, found := ok.search(key); found {
+		ok.keys = slices.Delete(ok.keys, i, i+1)
+		return
+	}
+	if i := slices.Index(ok.pending, key); i >= 0 {
+		ok.pending = slices.Delete(ok.pending, i, i+1)
+	}
+}
+
+// sorted merges the pending keys and returns all keys in order. The caller
+// must hold a lock of om.
+func (ok *OrderedKeys[K, V]) sorted() []K {
+	ok.mtx.Lock()
+	defer ok.mtx.Unlock()
+
+	if len(ok.pending) == 0 {
+		return ok.keys
+	}
+
+	// Sort only the pending keys, then merge them into the sorted keys from
+	// the back, so no key moves more than once
+	slices.SortFunc(ok.pending, ok.compare)
+	n := len(ok.keys)
+	ok.keys = slices.Grow(ok.keys, len(ok.pending))[:n+len(ok.pending)]
+	i, j := n-1, len(ok.pending)-1
+	for k := len(ok.keys) - 1; j >= 0; k-- {
+		if i >= 0 && ok.compare(ok.keys[i], ok.pending[j]) > 0 {
+			ok.keys[k] = ok.keys[i]
+			i--
+		} else {
+			ok.keys[k] = ok.pending[j]
+			j--
+		}
+	}
+	clear(ok.pending)
+	ok.pending = ok.pending[:0]
+	return ok.keys
+}
+
+// search finds the position of key in the sorted keys.
+func (ok *OrderedKeys[K, V]) search(key K) (int, bool) {
+	i, found := slices.BinarySearchFunc(ok.keys, key, ok.compare)
+	// Distinct keys may compare equal, look for the key itself among them
+	for ; found && i < len(ok.keys) && ok.compare(ok.keys[i], key) == 0; i++ {
+		if ok.keys[i] == key {
+			return i, true
+		}
+	}
+	return i, false
+}
+
+// Keys returns all keys of the RoarIndex in order.
+func (ok *OrderedKeys[K, V]) Keys() []K {
+	ok.om.mtx.RLock()
+	defer ok.om.mtx.RUnlock()
+
+	return slices.Clone(ok.sorted())
+}
+
+// KeysInRange returns the keys between lo and hi, both inclusive, in order.
+func (ok *OrderedKeys[K, V]) KeysInRange(lo, hi K) []K {
+	ok.om.mtx.RLock()
+	defer ok.om.mtx.RUnlock()
+
+	return slices.Clone(ok.keysInRange(lo, hi))
+}
+
+// keysInRange returns a subslice of the sorted keys between lo and hi. The
+// caller must hold a lock of om.
+func (ok *OrderedKeys[K, V]) keysInRange(lo, hi K) []K {
+	keys := ok.sorted()
+	start, _ := slices.BinarySearchFunc(keys, lo, ok.compare)
+	end := start
+	for end < len(keys) && ok.compare(keys[end], hi) <= 0 {
+		end++
+	}
+	return keys[start:end]
+}
+
+// UnionRange returns the values associated with any of the keys between lo
+// and hi, both inclusive.
+func (ok *OrderedKeys[K, V]) UnionRange(lo, hi K) []V {
+	ok.om.mtx.RLock()
+	defer ok.om.mtx.RUnlock()
+
+	return ok.om.valuesOf(roaring.FastOr(ok.om.bitmapsOf(ok.keysInRange(lo, hi))...))
+}
+
+// keysWithPrefix returns a subslice of the sorted keys starting with
+// prefix. The caller must hold a lock of om.
+func keysWithPrefix[K ~string, V comparable](ok *OrderedKeys[K, V], prefix K) []K {
+	keys := ok.sorted()
+	start, _ := slices.BinarySearchFunc(keys, prefix, ok.compare)
+	end := start
+	for end < len(keys) && strings.HasPrefix(string(keys[end]), string(prefix)) {
+		end++
+	}
+	return keys[start:end]
+}
+
+// KeysWithPrefix returns the keys starting with prefix, in order. The order
+// of ok must sort keys with a common prefix next to each other, as the
+// natural order of strings does.
+func KeysWithPrefix[K ~string, V comparable](ok *OrderedKeys[K, V], prefix K) []K {
+	ok.om.mtx.RLock()
+	defer ok.om.mtx.RUnlock()
+
+	return slices.Clone(keysWithPrefix(ok, prefix))
+}
+
+// UnionPrefix returns the values associated with any of the keys starting
+// with prefix, by ORing their bitmaps. The order of ok must be as for
+// KeysWithPrefix.
+func UnionPrefix[K ~string, V comparable](ok *OrderedKeys[K, V], prefix K) []V {
+	ok.om.mtx.RLock()
+	defer ok.om.mtx.RUnlock()
+
+	return ok.om.valuesOf(roaring.FastOr(ok.om.bitmapsOf(keysWithPrefix(ok, prefix))...))
+}
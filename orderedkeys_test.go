@@ -0,0 +1,105 @@
+package roarindex
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOrderedKeys(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	om.PushMap("tenant:42:b", 1)
+	om.PushMap("tenant:7:a", 2)
+
+	// Enabling indexes the existing keys, new keys are indexed as they are
+	// pushed
+	ok := EnableOrderedKeys(om)
+	om.PushMap("tenant:42:a", 3)
+	om.PushMap("tenant:42:a", 4)
+	om.PushMap("tenant:420:a", 5)
+	om.PushMap("other", 6)
+
+	if keys := ok.Keys(); !reflect.DeepEqual(keys, []string{"other", "tenant:420:a", "tenant:42:a", "tenant:42:b", "tenant:7:a"}) {
+		t.Errorf("Expected all keys in order, but got %v", keys)
+	}
+	if keys := KeysWithPrefix(ok, "tenant:42:"); !reflect.DeepEqual(keys, []string{"tenant:42:a", "tenant:42:b"}) {
+		t.Errorf("Expected [tenant:42:a tenant:42:b], but got %v", keys)
+	}
+	if keys := KeysWithPrefix(ok, "missing"); len(keys) != 0 {
+		t.Errorf("Expected no keys, but got %v", keys)
+	}
+	if keys := ok.KeysInRange("tenant:420", "tenant:42:a"); !reflect.DeepEqual(keys, []string{"tenant:420:a", "tenant:42:a"}) {
+		t.Errorf("Expected [tenant:420:a tenant:42:a], but got %v", keys)
+	}
+
+	values := UnionPrefix(ok, "tenant:42")
+	slices.Sort(values)
+	if !reflect.DeepEqual(values, []int{1, 3, 4, 5}) {
+		t.Errorf("Expected [1 3 4 5], but got %v", values)
+	}
+	if values := UnionPrefix(ok, "missing"); len(values) != 0 {
+		t.Errorf("Expected no values, but got %v", values)
+	}
+	values = ok.UnionRange("other", "tenant:42:a")
+	slices.Sort(values)
+	if !reflect.DeepEqual(values, []int{3, 4, 5, 6}) {
+		t.Errorf("Expected [3 4 5 6], but got %v", values)
+	}
+
+	om.DeleteMap("tenant:42:a")
+	if keys := KeysWithPrefix(ok, "tenant:42:"); !reflect.DeepEqual(keys, []string{"tenant:42:b"}) {
+		t.Errorf("Expected [tenant:42:b] after deleting, but got %v", keys)
+	}
+}
+
+func TestOrderedKeysFunc(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	ok := EnableOrderedKeysFunc(om, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	om.PushMap("b", 1)
+	om.PushMap("A", 2)
+	om.PushMap("a", 3)
+	om.PushMap("C", 4)
+
+	if keys := ok.KeysInRange("a", "b"); len(keys) != 3 || keys[2] != "b" {
+		t.Errorf("Expected A, a and b, but got %v", keys)
+	}
+
+	// Keys that compare equal are told apart when deleting
+	om.DeleteMap("a")
+	if keys := ok.Keys(); !reflect.DeepEqual(keys, []string{"A", "b", "C"}) {
+		t.Errorf("Expected [A b C], but got %v", keys)
+	}
+}
+
+func TestOrderedKeysInterleaved(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	ok := EnableOrderedKeys(om)
+	expected := make(map[string]bool)
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		key := fmt.Sprintf("key%d", rng.Intn(300))
+		switch rng.Intn(3) {
+		case 0:
+			om.PushMap(key, i)
+			expected[key] = true
+		case 1:
+			om.DeleteMap(key)
+			delete(expected, key)
+		case 2:
+			keys := make([]string, 0, len(expected))
+			for key := range expected {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			if got := ok.Keys(); !slices.Equal(got, keys) {
+				t.Fatalf("Step %d: expected %v, but got %v", i, keys, got)
+			}
+		}
+	}
+}
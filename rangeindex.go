@@ -0,0 +1,237 @@
+package roarindex
+
+import (
+	"math/bits"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// Integer is the set of value types a RangeIndex supports.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// RangeIndex is a bit-sliced index over the values of a RoarIndex. It keeps
+// one bitmap of value IDs per bit of the values, so range queries and
+// aggregates over the set of a key take a fixed number of bitmap operations
+// instead of a scan of the values. Queries return values in GetMap order.
+type RangeIndex[K comparable, V Integer] struct {
+	om *RoarIndex[K, V]
+
+	// Bitmaps of the value IDs whose encoded value has bit i set, guarded
+	// by the lock of om
+	slices []*roaring.Bitmap
+}
+
+// EnableRangeIndex starts maintaining a bit-sliced index over the values of
+// om. Existing values are indexed right away.
+func EnableRangeIndex[K comparable, V Integer](om *RoarIndex[K, V]) *RangeIndex[K, V] {
+	ri := &RangeIndex[K, V]{om: om}
+
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	om.backend.RangeValues(func(value V, valueID uint32) bool {
+		ri.addValue(valueID, value)
+		return true
+	})
+	om.valueIndexes = append(om.valueIndexes, ri)
+	return ri
+}
+
+// signed reports whether V is a signed integer type.
+func signed[V Integer]() bool {
+	return ^V(0) < 0
+}
+
+// encode maps value to an unsigned integer of the same order, flipping the
+// sign bit of signed values.
+func encode[V Integer](value V) uint64 {
+	if signed[V]() {
+		return uint64(int64(value)) ^ (1 << 63)
+	}
+	return uint64(value)
+}
+
+// decode is the inverse of encode.
+func decode[V Integer](u uint64) V {
+	if signed[V]() {
+		return V(int64(u ^ (1 << 63)))
+	}
+	return V(u)
+}
+
+// addValue indexes a new value. The caller must hold the write lock.
+func (ri *RangeIndex[K, V]) addValue(valueID uint32, value V) {
+	u := encode(value)
+	for n := bits.Len64(u); len(ri.slices) < n; {
+		ri.slices = append(ri.slices, roaring.NewBitmap())
+	}
+	for i := range ri.slices {
+		if u&(1<<i) != 0 {
+			ri.slices[i].Add(valueID)
+		}
+	}
+}
+
+// slice returns the bitmap of bit i, which is empty above the highest bit
+// of any value.
+func (ri *RangeIndex[K, V]) slice(i int, empty *roaring.Bitmap) *roaring.Bitmap {
+	if i < len(ri.slices) {
+		return ri.slices[i]
+	}
+	return empty
+}
+
+// compare splits the value IDs of found into those whose value is less
+// than, equal to and greater than value. The caller must hold the lock.
+func (ri *RangeIndex[K, V]) compare(found *roaring.Bitmap, value V) (lt, eq, gt *roaring.Bitmap) {
+	u := encode(value)
+	empty := roaring.NewBitmap()
+	lt, eq, gt = roaring.NewBitmap(), found.Clone(), roaring.NewBitmap()
+	for i := max(len(ri.slices), bits.Len64(u)) - 1; i >= 0 && !eq.IsEmpty(); i-- {
+		slice := ri.slice(i, empty)
+		if u&(1<<i) != 0 {
+			lt.Or(roaring.AndNot(eq, slice))
+			eq.And(slice)
+		} else {
+			gt.Or(roaring.And(eq, slice))
+			eq.AndNot(slice)
+		}
+	}
+	return lt, eq, gt
+}
+
+// query compares the values associated with key against value and returns
+// the ones pick selects.
+func (ri *RangeIndex[K, V]) query(key K, value V, pick func(lt, eq, gt *roaring.Bitmap) *roaring.Bitmap) ([]V, error) {
+	om := ri.om
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil {
+		return nil, err
+	}
+	values := om.valuesOf(pick(ri.compare(bm, value)))
+	om.sortValues(values)
+	return values, nil
+}
+
+// GetMapRange retrieves the values associated with key between lo and hi,
+// both inclusive.
+func (ri *RangeIndex[K, V]) GetMapRange(key K, lo, hi V) ([]V, error) {
+	om := ri.om
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil {
+		return nil, err
+	}
+	if hi < lo {
+		return []V{}, nil
+	}
+	_, eq, gt := ri.compare(bm, lo)
+	atLeast := roaring.Or(eq, gt)
+	lt, eq, _ := ri.compare(atLeast, hi)
+	values := om.valuesOf(roaring.Or(lt, eq))
+	om.sortValues(values)
+	return values, nil
+}
+
+// GetMapGreaterOrEqual retrieves the values associated with key that are
+// greater than or equal to value.
+func (ri *RangeIndex[K, V]) GetMapGreaterOrEqual(key K, value V) ([]V, error) {
+	return ri.query(key, value, func(_, eq, gt *roaring.Bitmap) *roaring.Bitmap {
+		return roaring.Or(eq, gt)
+	})
+}
+
+// GetMapLessOrEqual retrieves the values associated with key that are less
+// than or equal to value.
+func (ri *RangeIndex[K, V]) GetMapLessOrEqual(key K, value V) ([]V, error) {
+	return ri.query(key, value, func(lt, eq, _ *roaring.Bitmap) *roaring.Bitmap {
+		return roaring.Or(lt, eq)
+	})
+}
+
+// GetMapEqual retrieves the values associated with key that equal value,
+// which is either none or value itself.
+func (ri *RangeIndex[K, V]) GetMapEqual(key K, value V) ([]V, error) {
+	return ri.query(key, value, func(_, eq, _ *roaring.Bitmap) *roaring.Bitmap {
+		return eq
+	})
+}
+
+// Sum returns the sum and the number of the values associated with key.
+// The sum wraps around on overflow of int64.
+func (ri *RangeIndex[K, V]) Sum(key K) (sum int64, count uint64, err error) {
+	om := ri.om
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Sum the encoded values bit by bit, then undo the offset of the sign
+	// flip for signed values
+	var total uint64
+	for i, slice := range ri.slices {
+		total += bm.AndCardinality(slice) << i
+	}
+	count = bm.GetCardinality()
+	if signed[V]() {
+		total -= count << 63
+	}
+	return int64(total), count, nil
+}
+
+// Min returns the smallest value associated with key, or false if key is
+// not in the RoarIndex or has no values.
+func (ri *RangeIndex[K, V]) Min(key K) (V, bool) {
+	return ri.extreme(key, false)
+}
+
+// Max returns the largest value associated with key, or false if key is
+// not in the RoarIndex or has no values.
+func (ri *RangeIndex[K, V]) Max(key K) (V, bool) {
+	return ri.extreme(key, true)
+}
+
+// extreme narrows the value IDs of key bit by bit from the highest one,
+// preferring set bits for the maximum and clear bits for the minimum.
+func (ri *RangeIndex[K, V]) extreme(key K, largest bool) (V, bool) {
+	om := ri.om
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil || bm.IsEmpty() {
+		return 0, false
+	}
+
+	var u uint64
+	candidates := bm
+	for i := len(ri.slices) - 1; i >= 0; i-- {
+		var narrowed *roaring.Bitmap
+		if largest {
+			narrowed = roaring.And(candidates, ri.slices[i])
+		} else {
+			narrowed = roaring.AndNot(candidates, ri.slices[i])
+		}
+		switch {
+		case !narrowed.IsEmpty():
+			candidates = narrowed
+			if largest {
+				u |= 1 << i
+			}
+		case !largest:
+			u |= 1 << i
+		}
+	}
+	return decode[V](u), true
+}
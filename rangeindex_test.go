@@ -0,0 +1,127 @@
+package roarindex
+
+import (
+	"cmp"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestRangeIndex(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	for _, value := range []int{-40, -3, 0, 7, 12} {
+		om.PushMap("temps", value)
+	}
+	om.PushMap("other", 5)
+
+	// Enabling indexes the existing values, new values are indexed as they
+	// are pushed
+	ri := EnableRangeIndex(om)
+	om.PushMap("temps", 25)
+	om.PushMap("extremes", math.MinInt64)
+	om.PushMap("extremes", math.MaxInt64)
+
+	sorted := func(values []int, err error) []int {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		slices.Sort(values)
+		return values
+	}
+
+	tests := []struct {
+		name     string
+		values   []int
+		expected []int
+	}{
+		{"Range", sorted(ri.GetMapRange("temps", -3, 12)), []int{-3, 0, 7, 12}},
+		{"RangeEmpty", sorted(ri.GetMapRange("temps", 8, 11)), []int{}},
+		{"RangeReversed", sorted(ri.GetMapRange("temps", 12, -3)), []int{}},
+		{"GreaterOrEqual", sorted(ri.GetMapGreaterOrEqual("temps", 7)), []int{7, 12, 25}},
+		{"LessOrEqual", sorted(ri.GetMapLessOrEqual("temps", -1)), []int{-40, -3}},
+		{"Equal", sorted(ri.GetMapEqual("temps", 0)), []int{0}},
+		{"Extremes", sorted(ri.GetMapRange("extremes", math.MinInt64, math.MaxInt64)), []int{math.MinInt64, math.MaxInt64}},
+		{"EqualOtherKey", sorted(ri.GetMapEqual("temps", 5)), []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.values, tt.expected) {
+				t.Errorf("Expected %v, but got %v", tt.expected, tt.values)
+			}
+		})
+	}
+
+	if _, err := ri.GetMapRange("nonExistent", 0, 1); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, but got %v", err)
+	}
+
+	if min, ok := ri.Min("temps"); !ok || min != -40 {
+		t.Errorf("Expected minimum -40, but got %d and %v", min, ok)
+	}
+	if min, ok := ri.Min("extremes"); !ok || min != math.MinInt64 {
+		t.Errorf("Expected minimum %d, but got %d and %v", math.MinInt64, min, ok)
+	}
+	if max, ok := ri.Max("temps"); !ok || max != 25 {
+		t.Errorf("Expected maximum 25, but got %d and %v", max, ok)
+	}
+	if _, ok := ri.Max("nonExistent"); ok {
+		t.Errorf("Expected no maximum for a missing key")
+	}
+
+	if sum, count, err := ri.Sum("temps"); err != nil || sum != 1 || count != 6 {
+		t.Errorf("Expected sum 1 of 6 values, but got %d of %d and %v", sum, count, err)
+	}
+	if sum, count, err := ri.Sum("extremes"); err != nil || sum != -1 || count != 2 {
+		t.Errorf("Expected sum -1 of 2 values, but got %d of %d and %v", sum, count, err)
+	}
+
+	// Values of deleted keys no longer count
+	om.DeleteMap("other")
+	if _, _, err := ri.Sum("other"); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound after deleting, but got %v", err)
+	}
+}
+
+func TestRangeIndexFollowsOrder(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	ri := EnableRangeIndex(om)
+	for _, value := range []int{3, 1, 4, 2} {
+		om.PushMap("key1", value)
+	}
+	om.SetOrderFunc(nil, cmp.Compare[int])
+
+	if values, _ := ri.GetMapRange("key1", 2, 4); !reflect.DeepEqual(values, []int{2, 3, 4}) {
+		t.Errorf("Expected [2 3 4], but got %v", values)
+	}
+	if values, _ := ri.GetMapGreaterOrEqual("key1", 1); !reflect.DeepEqual(values, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected [1 2 3 4], but got %v", values)
+	}
+}
+
+func TestRangeIndexUnsigned(t *testing.T) {
+	om := NewRoarIndex[string, uint8]()
+	ri := EnableRangeIndex(om)
+	for _, value := range []uint8{1, 2, 200, 255} {
+		om.PushMap("bytes", value)
+	}
+
+	values, err := ri.GetMapGreaterOrEqual("bytes", 2)
+	if err != nil {
+		t.Fatalf("GetMapGreaterOrEqual failed: %v", err)
+	}
+	slices.Sort(values)
+	if !reflect.DeepEqual(values, []uint8{2, 200, 255}) {
+		t.Errorf("Expected [2 200 255], but got %v", values)
+	}
+	if sum, count, err := ri.Sum("bytes"); err != nil || sum != 458 || count != 4 {
+		t.Errorf("Expected sum 458 of 4 values, but got %d of %d and %v", sum, count, err)
+	}
+	if min, ok := ri.Min("bytes"); !ok || min != 1 {
+		t.Errorf("Expected minimum 1, but got %d and %v", min, ok)
+	}
+	if max, ok := ri.Max("bytes"); !ok || max != 255 {
+		t.Errorf("Expected maximum 255, but got %d and %v", max, ok)
+	}
+}
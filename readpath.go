@@ -0,0 +1,68 @@
+package roarindex
+
+import (
+	"slices"
+)
+
+// AppendMap appends the values associated with key to dst, in GetMap
+// order, and returns the extended slice. Reusing dst between calls avoids
+// allocating a result slice per call.
+func (om *RoarIndex[K, V]) AppendMap(dst []V, key K) ([]V, error) {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil {
+		return dst, err
+	}
+
+	start := len(dst)
+	dst = slices.Grow(dst, int(bm.GetCardinality()))
+	bm.Iterate(func(valueID uint32) bool {
+		if value, valueExists := om.backend.Value(valueID); valueExists {
+			dst = append(dst, value)
+		}
+		return true
+	})
+	om.sortValues(dst[start:])
+	return dst, nil
+}
+
+// ValueIDs appends the internal IDs of the values associated with key to
+// dst in ascending order and returns the extended slice.
+func (om *RoarIndex[K, V]) ValueIDs(key K, dst []uint32) ([]uint32, error) {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil {
+		return dst, err
+	}
+
+	dst = slices.Grow(dst, int(bm.GetCardinality()))
+	bm.Iterate(func(valueID uint32) bool {
+		dst = append(dst, valueID)
+		return true
+	})
+	return dst, nil
+}
+
+// ForEach calls fn for each value associated with key, in the order they
+// were first pushed to any key, until fn returns false. It does not
+// allocate. The read lock is held meanwhile, so fn must not modify the
+// RoarIndex.
+func (om *RoarIndex[K, V]) ForEach(key K, fn func(V) bool) error {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil {
+		return err
+	}
+
+	bm.Iterate(func(valueID uint32) bool {
+		value, valueExists := om.backend.Value(valueID)
+		return !valueExists || fn(value)
+	})
+	return nil
+}
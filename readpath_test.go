@@ -0,0 +1,94 @@
+package roarindex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoarIndexAppendMap(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	for _, v := range []int{3, 1, 2} {
+		om.PushMap("map1", v)
+	}
+
+	values, err := om.AppendMap([]int{42}, "map1")
+	if err != nil {
+		t.Fatalf("AppendMap failed: %v", err)
+	}
+	if !reflect.DeepEqual(values, []int{42, 3, 1, 2}) {
+		t.Errorf("Expected [42 3 1 2], but got %v", values)
+	}
+
+	if _, err := om.AppendMap(nil, "nonExistent"); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, but got %v", err)
+	}
+
+	buf := make([]int, 0, 16)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = om.AppendMap(buf[:0], "map1")
+	})
+	if allocs != 0 {
+		t.Errorf("Expected AppendMap into a large enough buffer not to allocate, but got %v allocs", allocs)
+	}
+}
+
+func TestRoarIndexValueIDs(t *testing.T) {
+	om := NewRoarIndex[string, string]()
+	om.PushMap("map1", "value1")
+	om.PushMap("map2", "value2")
+	om.PushMap("map1", "value3")
+
+	ids, err := om.ValueIDs("map1", []uint32{99})
+	if err != nil {
+		t.Fatalf("ValueIDs failed: %v", err)
+	}
+	if !reflect.DeepEqual(ids, []uint32{99, 0, 2}) {
+		t.Errorf("Expected [99 0 2], but got %v", ids)
+	}
+
+	if _, err := om.ValueIDs("nonExistent", nil); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, but got %v", err)
+	}
+
+	buf := make([]uint32, 0, 16)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = om.ValueIDs("map1", buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("Expected ValueIDs into a large enough buffer not to allocate, but got %v allocs", allocs)
+	}
+}
+
+func TestRoarIndexForEach(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	for v := 0; v < 200; v++ {
+		om.PushMap("map1", v)
+	}
+
+	var seen []int
+	err := om.ForEach("map1", func(v int) bool {
+		seen = append(seen, v)
+		return v < 99
+	})
+	if err != nil {
+		t.Fatalf("ForEach failed: %v", err)
+	}
+	if len(seen) != 100 || seen[0] != 0 || seen[99] != 99 {
+		t.Errorf("Expected ForEach to stop after 100 values, but got %d", len(seen))
+	}
+
+	if err := om.ForEach("nonExistent", func(int) bool { return true }); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, but got %v", err)
+	}
+
+	sum := 0
+	allocs := testing.AllocsPerRun(100, func() {
+		om.ForEach("map1", func(v int) bool {
+			sum += v
+			return true
+		})
+	})
+	if allocs != 0 {
+		t.Errorf("Expected ForEach not to allocate, but got %v allocs", allocs)
+	}
+}
@@ -0,0 +1,182 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/thisisdevelopment/roarindex"
+	"github.com/thisisdevelopment/roarindex/remote/remotepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ roarindex.Index[string, string] = (*Client)(nil)
+
+// Client is a roarindex.Index backed by a remote Server.
+//
+// Of the roarindex.Index methods only GetMap can return an error: the first
+// error hit by any other method is kept and reported by Err until ResetErr
+// is called, later errors are dropped meanwhile, and the failed call returns
+// the zero value.
+type Client struct {
+	rpc remotepb.RoarIndexClient
+
+	// Timeout bounds each call when positive.
+	Timeout time.Duration
+
+	mtx sync.Mutex
+	err error
+}
+
+// NewClient creates a new Client using conn.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{rpc: remotepb.NewRoarIndexClient(conn)}
+}
+
+// Err returns the first error the Client ran into outside of GetMap, if any.
+func (c *Client) Err() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.err
+}
+
+// ResetErr forgets the recorded error, so Err reports the next one.
+func (c *Client) ResetErr() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.err = nil
+}
+
+// PushMap implements roarindex.Index.
+func (c *Client) PushMap(key string, value string) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	_, err := c.rpc.Push(ctx, &remotepb.PushRequest{Key: key, Values: []string{value}})
+	c.setErr(err)
+}
+
+// GetMap implements roarindex.Index. It returns roarindex.ErrKeyNotFound
+// when the key does not exist.
+func (c *Client) GetMap(key string) ([]string, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	stream, err := c.rpc.StreamGet(ctx, &remotepb.GetRequest{Key: key})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	values, err := receiveChunks(stream)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return values, nil
+}
+
+// HasValue implements roarindex.Index.
+func (c *Client) HasValue(key string, value string) bool {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	resp, err := c.rpc.Has(ctx, &remotepb.HasRequest{Key: key, Value: value})
+	c.setErr(err)
+	return resp.GetHas()
+}
+
+// DeleteMap implements roarindex.Index.
+func (c *Client) DeleteMap(key string) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	_, err := c.rpc.Delete(ctx, &remotepb.DeleteRequest{Key: key})
+	c.setErr(err)
+}
+
+// Keys implements roarindex.Index.
+func (c *Client) Keys() []string {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	stream, err := c.rpc.StreamKeys(ctx, &remotepb.StreamKeysRequest{})
+	if err != nil {
+		c.setErr(err)
+		return nil
+	}
+	keys, err := receiveChunks(stream)
+	c.setErr(err)
+	return keys
+}
+
+// Values implements roarindex.Index.
+func (c *Client) Values() []string {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	stream, err := c.rpc.StreamValues(ctx, &remotepb.StreamValuesRequest{})
+	if err != nil {
+		c.setErr(err)
+		return nil
+	}
+	values, err := receiveChunks(stream)
+	c.setErr(err)
+	return values
+}
+
+// Count implements roarindex.Index.
+func (c *Client) Count() int {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	resp, err := c.rpc.Count(ctx, &remotepb.CountRequest{})
+	c.setErr(err)
+	return int(resp.GetCount())
+}
+
+func (c *Client) context() (context.Context, context.CancelFunc) {
+	if c.Timeout > 0 {
+		return context.WithTimeout(context.Background(), c.Timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// setErr records err unless it is nil or an earlier error was recorded.
+func (c *Client) setErr(err error) {
+	if err == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+func receiveChunks(stream grpc.ServerStreamingClient[remotepb.Chunk]) ([]string, error) {
+	var items []string
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, chunk.GetItems()...)
+	}
+}
+
+// fromStatus converts gRPC status errors back to index errors.
+func fromStatus(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return roarindex.ErrKeyNotFound
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/thisisdevelopment/roarindex"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func startServer(t *testing.T) (*roarindex.RoarIndex[string, string], *Client) {
+	t.Helper()
+	index := roarindex.NewRoarIndex[string, string]()
+
+	l := bufconn.Listen(1 << 20)
+	g := grpc.NewServer()
+	NewServer(index).Register(g)
+	go g.Serve(l)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return index, NewClient(conn)
+}
+
+// exercise runs the same calls against a local or remote index.
+func exercise(t *testing.T, index roarindex.Index[string, string]) {
+	t.Helper()
+	index.PushMap("key1", "value1")
+	index.PushMap("key1", "value2")
+	index.PushMap("key2", "value2")
+	index.PushMap("key3", "value3")
+	index.DeleteMap("key3")
+
+	values, err := index.GetMap("key1")
+	if err != nil {
+		t.Errorf("Expected key1 to exist, got error: %v", err)
+	}
+	if !slices.Equal(values, []string{"value1", "value2"}) {
+		t.Errorf("Expected [value1 value2], got %v", values)
+	}
+	if _, err := index.GetMap("key3"); err != roarindex.ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+	if !index.HasValue("key2", "value2") || index.HasValue("key2", "value1") {
+		t.Errorf("HasValue returned wrong results for key2")
+	}
+	if n := index.Count(); n != 2 {
+		t.Errorf("Expected 2 keys, got %d", n)
+	}
+	keys := index.Keys()
+	slices.Sort(keys)
+	if !slices.Equal(keys, []string{"key1", "key2"}) {
+		t.Errorf("Expected [key1 key2], got %v", keys)
+	}
+	if n := len(index.Values()); n != 3 {
+		t.Errorf("Expected 3 values, got %d", n)
+	}
+}
+
+func TestClientMatchesLocalIndex(t *testing.T) {
+	exercise(t, roarindex.NewRoarIndex[string, string]())
+
+	_, client := startServer(t)
+	exercise(t, client)
+	if err := client.Err(); err != nil {
+		t.Errorf("Expected no client error, got %v", err)
+	}
+}
+
+func TestClientStreamsLargeResults(t *testing.T) {
+	index, client := startServer(t)
+	for i := 0; i < ChunkSize*3+7; i++ {
+		index.PushMap("big", fmt.Sprintf("value%d", i))
+		index.PushMap(fmt.Sprintf("key%d", i), "value")
+	}
+
+	values, err := client.GetMap("big")
+	if err != nil {
+		t.Errorf("Expected big to exist, got error: %v", err)
+	}
+	if len(values) != ChunkSize*3+7 || values[ChunkSize*3+6] != fmt.Sprintf("value%d", ChunkSize*3+6) {
+		t.Errorf("Expected all values of big in order, got %d values", len(values))
+	}
+	if n := len(client.Keys()); n != index.Count() {
+		t.Errorf("Expected %d keys, got %d", index.Count(), n)
+	}
+}
+
+func TestClientRecordsErrors(t *testing.T) {
+	conn, err := grpc.NewClient("passthrough:///unreachable",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return nil, net.ErrClosed }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer conn.Close()
+
+	client := NewClient(conn)
+	if client.Count() != 0 || client.Err() == nil {
+		t.Errorf("Expected Count to fail and record the error")
+	}
+	if _, err := client.GetMap("key1"); err == nil || err == roarindex.ErrKeyNotFound {
+		t.Errorf("Expected GetMap to return the transport error, got %v", err)
+	}
+
+	client.ResetErr()
+	if err := client.Err(); err != nil {
+		t.Errorf("Expected no error after ResetErr, got %v", err)
+	}
+	if client.HasValue("key1", "value1") || client.Err() == nil {
+		t.Errorf("Expected HasValue to fail and record the error")
+	}
+}
@@ -0,0 +1,5 @@
+// Package remotepb holds the protobuf messages and gRPC stubs of the
+// RoarIndex service.
+package remotepb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative roarindex.proto
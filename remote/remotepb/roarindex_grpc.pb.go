@@ -0,0 +1,397 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: roarindex.proto
+
+package remotepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RoarIndex_Push_FullMethodName         = "/roarindex.v1.RoarIndex/Push"
+	RoarIndex_Get_FullMethodName          = "/roarindex.v1.RoarIndex/Get"
+	RoarIndex_Has_FullMethodName          = "/roarindex.v1.RoarIndex/Has"
+	RoarIndex_Delete_FullMethodName       = "/roarindex.v1.RoarIndex/Delete"
+	RoarIndex_Count_FullMethodName        = "/roarindex.v1.RoarIndex/Count"
+	RoarIndex_StreamGet_FullMethodName    = "/roarindex.v1.RoarIndex/StreamGet"
+	RoarIndex_StreamKeys_FullMethodName   = "/roarindex.v1.RoarIndex/StreamKeys"
+	RoarIndex_StreamValues_FullMethodName = "/roarindex.v1.RoarIndex/StreamValues"
+)
+
+// RoarIndexClient is the client API for RoarIndex service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RoarIndexClient interface {
+	Push(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*PushResponse, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	Has(ctx context.Context, in *HasRequest, opts ...grpc.CallOption) (*HasResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error)
+	StreamGet(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Chunk], error)
+	StreamKeys(ctx context.Context, in *StreamKeysRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Chunk], error)
+	StreamValues(ctx context.Context, in *StreamValuesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Chunk], error)
+}
+
+type roarIndexClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRoarIndexClient(cc grpc.ClientConnInterface) RoarIndexClient {
+	return &roarIndexClient{cc}
+}
+
+func (c *roarIndexClient) Push(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*PushResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushResponse)
+	err := c.cc.Invoke(ctx, RoarIndex_Push_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roarIndexClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, RoarIndex_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roarIndexClient) Has(ctx context.Context, in *HasRequest, opts ...grpc.CallOption) (*HasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HasResponse)
+	err := c.cc.Invoke(ctx, RoarIndex_Has_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roarIndexClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, RoarIndex_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roarIndexClient) Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountResponse)
+	err := c.cc.Invoke(ctx, RoarIndex_Count_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roarIndexClient) StreamGet(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Chunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoarIndex_ServiceDesc.Streams[0], RoarIndex_StreamGet_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetRequest, Chunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoarIndex_StreamGetClient = grpc.ServerStreamingClient[Chunk]
+
+func (c *roarIndexClient) StreamKeys(ctx context.Context, in *StreamKeysRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Chunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoarIndex_ServiceDesc.Streams[1], RoarIndex_StreamKeys_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamKeysRequest, Chunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoarIndex_StreamKeysClient = grpc.ServerStreamingClient[Chunk]
+
+func (c *roarIndexClient) StreamValues(ctx context.Context, in *StreamValuesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Chunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoarIndex_ServiceDesc.Streams[2], RoarIndex_StreamValues_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamValuesRequest, Chunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoarIndex_StreamValuesClient = grpc.ServerStreamingClient[Chunk]
+
+// RoarIndexServer is the server API for RoarIndex service.
+// All implementations must embed UnimplementedRoarIndexServer
+// for forward compatibility.
+type RoarIndexServer interface {
+	Push(context.Context, *PushRequest) (*PushResponse, error)
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	Has(context.Context, *HasRequest) (*HasResponse, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Count(context.Context, *CountRequest) (*CountResponse, error)
+	StreamGet(*GetRequest, grpc.ServerStreamingServer[Chunk]) error
+	StreamKeys(*StreamKeysRequest, grpc.ServerStreamingServer[Chunk]) error
+	StreamValues(*StreamValuesRequest, grpc.ServerStreamingServer[Chunk]) error
+	mustEmbedUnimplementedRoarIndexServer()
+}
+
+// UnimplementedRoarIndexServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRoarIndexServer struct{}
+
+func (UnimplementedRoarIndexServer) Push(context.Context, *PushRequest) (*PushResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
+}
+func (UnimplementedRoarIndexServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedRoarIndexServer) Has(context.Context, *HasRequest) (*HasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Has not implemented")
+}
+func (UnimplementedRoarIndexServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedRoarIndexServer) Count(context.Context, *CountRequest) (*CountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (UnimplementedRoarIndexServer) StreamGet(*GetRequest, grpc.ServerStreamingServer[Chunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamGet not implemented")
+}
+func (UnimplementedRoarIndexServer) StreamKeys(*StreamKeysRequest, grpc.ServerStreamingServer[Chunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamKeys not implemented")
+}
+func (UnimplementedRoarIndexServer) StreamValues(*StreamValuesRequest, grpc.ServerStreamingServer[Chunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamValues not implemented")
+}
+func (UnimplementedRoarIndexServer) mustEmbedUnimplementedRoarIndexServer() {}
+func (UnimplementedRoarIndexServer) testEmbeddedByValue()                   {}
+
+// UnsafeRoarIndexServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RoarIndexServer will
+// result in compilation errors.
+type UnsafeRoarIndexServer interface {
+	mustEmbedUnimplementedRoarIndexServer()
+}
+
+func RegisterRoarIndexServer(s grpc.ServiceRegistrar, srv RoarIndexServer) {
+	// If the following call pancis, it indicates UnimplementedRoarIndexServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RoarIndex_ServiceDesc, srv)
+}
+
+func _RoarIndex_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoarIndexServer).Push(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoarIndex_Push_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoarIndexServer).Push(ctx, req.(*PushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoarIndex_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoarIndexServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoarIndex_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoarIndexServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoarIndex_Has_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoarIndexServer).Has(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoarIndex_Has_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoarIndexServer).Has(ctx, req.(*HasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoarIndex_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoarIndexServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoarIndex_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoarIndexServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoarIndex_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoarIndexServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoarIndex_Count_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoarIndexServer).Count(ctx, req.(*CountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoarIndex_StreamGet_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoarIndexServer).StreamGet(m, &grpc.GenericServerStream[GetRequest, Chunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoarIndex_StreamGetServer = grpc.ServerStreamingServer[Chunk]
+
+func _RoarIndex_StreamKeys_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamKeysRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoarIndexServer).StreamKeys(m, &grpc.GenericServerStream[StreamKeysRequest, Chunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoarIndex_StreamKeysServer = grpc.ServerStreamingServer[Chunk]
+
+func _RoarIndex_StreamValues_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamValuesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoarIndexServer).StreamValues(m, &grpc.GenericServerStream[StreamValuesRequest, Chunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoarIndex_StreamValuesServer = grpc.ServerStreamingServer[Chunk]
+
+// RoarIndex_ServiceDesc is the grpc.ServiceDesc for RoarIndex service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RoarIndex_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "roarindex.v1.RoarIndex",
+	HandlerType: (*RoarIndexServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Push",
+			Handler:    _RoarIndex_Push_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _RoarIndex_Get_Handler,
+		},
+		{
+			MethodName: "Has",
+			Handler:    _RoarIndex_Has_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _RoarIndex_Delete_Handler,
+		},
+		{
+			MethodName: "Count",
+			Handler:    _RoarIndex_Count_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamGet",
+			Handler:       _RoarIndex_StreamGet_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamKeys",
+			Handler:       _RoarIndex_StreamKeys_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamValues",
+			Handler:       _RoarIndex_StreamValues_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "roarindex.proto",
+}
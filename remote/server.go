@@ -0,0 +1,115 @@
+// Package remote serves a RoarIndex over gRPC and provides a client that
+// implements roarindex.Index, so callers can swap a local index for a
+// remote one. The service is defined in remotepb/roarindex.proto.
+package remote
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thisisdevelopment/roarindex"
+	"github.com/thisisdevelopment/roarindex/remote/remotepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ChunkSize is the number of items sent per message by streaming calls.
+const ChunkSize = 1024
+
+// Server implements the RoarIndex gRPC service for a RoarIndex.
+type Server struct {
+	remotepb.UnimplementedRoarIndexServer
+
+	index *roarindex.RoarIndex[string, string]
+}
+
+// NewServer creates a new Server for index.
+func NewServer(index *roarindex.RoarIndex[string, string]) *Server {
+	return &Server{index: index}
+}
+
+// Register registers the Server with a gRPC server.
+func (s *Server) Register(g grpc.ServiceRegistrar) {
+	remotepb.RegisterRoarIndexServer(g, s)
+}
+
+// Push implements remotepb.RoarIndexServer.
+func (s *Server) Push(_ context.Context, req *remotepb.PushRequest) (*remotepb.PushResponse, error) {
+	s.index.PushValues(req.GetKey(), req.GetValues()...)
+	return &remotepb.PushResponse{}, nil
+}
+
+// Get implements remotepb.RoarIndexServer.
+func (s *Server) Get(ctx context.Context, req *remotepb.GetRequest) (*remotepb.GetResponse, error) {
+	values, err := s.index.GetMapCtx(ctx, req.GetKey())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &remotepb.GetResponse{Values: values}, nil
+}
+
+// Has implements remotepb.RoarIndexServer.
+func (s *Server) Has(_ context.Context, req *remotepb.HasRequest) (*remotepb.HasResponse, error) {
+	return &remotepb.HasResponse{Has: s.index.HasValue(req.GetKey(), req.GetValue())}, nil
+}
+
+// Delete implements remotepb.RoarIndexServer.
+func (s *Server) Delete(_ context.Context, req *remotepb.DeleteRequest) (*remotepb.DeleteResponse, error) {
+	s.index.DeleteMap(req.GetKey())
+	return &remotepb.DeleteResponse{}, nil
+}
+
+// Count implements remotepb.RoarIndexServer.
+func (s *Server) Count(context.Context, *remotepb.CountRequest) (*remotepb.CountResponse, error) {
+	return &remotepb.CountResponse{Count: int64(s.index.Count())}, nil
+}
+
+// StreamGet implements remotepb.RoarIndexServer.
+func (s *Server) StreamGet(req *remotepb.GetRequest, stream grpc.ServerStreamingServer[remotepb.Chunk]) error {
+	values, err := s.index.GetMapCtx(stream.Context(), req.GetKey())
+	if err != nil {
+		return toStatus(err)
+	}
+	return sendChunks(stream, values)
+}
+
+// StreamKeys implements remotepb.RoarIndexServer.
+func (s *Server) StreamKeys(_ *remotepb.StreamKeysRequest, stream grpc.ServerStreamingServer[remotepb.Chunk]) error {
+	keys, err := s.index.KeysCtx(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	return sendChunks(stream, keys)
+}
+
+// StreamValues implements remotepb.RoarIndexServer.
+func (s *Server) StreamValues(_ *remotepb.StreamValuesRequest, stream grpc.ServerStreamingServer[remotepb.Chunk]) error {
+	values, err := s.index.ValuesCtx(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	return sendChunks(stream, values)
+}
+
+func sendChunks(stream grpc.ServerStreamingServer[remotepb.Chunk], items []string) error {
+	for len(items) > 0 {
+		n := min(ChunkSize, len(items))
+		if err := stream.Send(&remotepb.Chunk{Items: items[:n]}); err != nil {
+			return err
+		}
+		items = items[n:]
+	}
+	return nil
+}
+
+// toStatus converts index errors to gRPC status errors.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, roarindex.ErrKeyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
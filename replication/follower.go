@@ -0,0 +1,169 @@
+package replication
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/thisisdevelopment/roarindex"
+)
+
+// ErrSequenceGap is returned by Follow when the primary skips entries.
+var ErrSequenceGap = errors.New("replication: gap in sequence numbers")
+
+// ErrUnexpectedFrame is returned by Follow when the primary sends a frame
+// out of place.
+var ErrUnexpectedFrame = errors.New("replication: unexpected frame")
+
+// Follower applies the log of a Primary to a local copy of its index. It
+// implements the read methods of roarindex.Index.
+type Follower[K comparable, V comparable] struct {
+	mtx   sync.RWMutex
+	index *roarindex.RoarIndex[K, V]
+	seq   uint64
+}
+
+// NewFollower creates a new Follower for index, which holds the entries up
+// to and including seq. Pass a new index and 0 to replicate from scratch,
+// or an index read from a snapshot and the sequence number returned by
+// Primary.WriteSnapshot to bootstrap from it.
+func NewFollower[K comparable, V comparable](index *roarindex.RoarIndex[K, V], seq uint64) *Follower[K, V] {
+	return &Follower[K, V]{index: index, seq: seq}
+}
+
+// Seq returns the sequence number of the last entry applied, a Follow
+// resumes after it.
+func (f *Follower[K, V]) Seq() uint64 {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+
+	return f.seq
+}
+
+// Index returns the local copy of the index. It is replaced when the
+// primary sends a snapshot, so callers should not hold on to it.
+func (f *Follower[K, V]) Index() *roarindex.RoarIndex[K, V] {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+
+	return f.index
+}
+
+// GetMap retrieves the set of values associated with a key.
+func (f *Follower[K, V]) GetMap(key K) ([]V, error) {
+	return f.Index().GetMap(key)
+}
+
+// HasValue checks if a value exists for a key.
+func (f *Follower[K, V]) HasValue(key K, value V) bool {
+	return f.Index().HasValue(key, value)
+}
+
+// Keys returns all keys.
+func (f *Follower[K, V]) Keys() []K {
+	return f.Index().Keys()
+}
+
+// Values returns all values.
+func (f *Follower[K, V]) Values() []V {
+	return f.Index().Values()
+}
+
+// Count returns the number of keys.
+func (f *Follower[K, V]) Count() int {
+	return f.Index().Count()
+}
+
+// Follow asks the primary at the other end of rw for the entries after Seq
+// and applies them until the connection ends. It returns
+// io.ErrUnexpectedEOF when the primary closes the connection, and the read
+// error, such as net.ErrClosed, when the caller does. Call Follow again on
+// a new connection to resume; only one Follow may run at a time.
+func (f *Follower[K, V]) Follow(rw io.ReadWriter) error {
+	if err := gob.NewEncoder(rw).Encode(request{From: f.Seq()}); err != nil {
+		return err
+	}
+
+	dec := gob.NewDecoder(bufio.NewReader(rw))
+	for {
+		var fr frame[K, V]
+		if err := dec.Decode(&fr); err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+
+		var err error
+		switch fr.Kind {
+		case frameEntries:
+			err = f.apply(fr.Entries)
+		case frameSnapshotStart:
+			err = f.readSnapshot(dec, fr.Seq)
+		default:
+			err = fmt.Errorf("%w: kind %d", ErrUnexpectedFrame, fr.Kind)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// apply applies entries in order, skipping those already applied.
+func (f *Follower[K, V]) apply(entries []Entry[K, V]) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	for _, entry := range entries {
+		if entry.Seq <= f.seq {
+			continue
+		}
+		if entry.Seq != f.seq+1 {
+			return fmt.Errorf("%w: expected %d, got %d", ErrSequenceGap, f.seq+1, entry.Seq)
+		}
+
+		switch entry.Op {
+		case OpPush:
+			f.index.PushMap(entry.Key, entry.Value)
+		case OpDelete:
+			f.index.DeleteMap(entry.Key)
+		default:
+			return fmt.Errorf("replication: unknown op %d", entry.Op)
+		}
+		f.seq = entry.Seq
+	}
+	return nil
+}
+
+// readSnapshot reads the snapshot data frames up to the end frame into a
+// new index, which replaces the current one. Reads keep using the current
+// index meanwhile.
+func (f *Follower[K, V]) readSnapshot(dec *gob.Decoder, seq uint64) error {
+	index := roarindex.NewRoarIndex[K, V]()
+	for {
+		var fr frame[K, V]
+		if err := dec.Decode(&fr); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+
+		switch fr.Kind {
+		case frameSnapshotData:
+			for _, entry := range fr.Keys {
+				index.PushValues(entry.Key, entry.Values...)
+			}
+		case frameSnapshotEnd:
+			f.mtx.Lock()
+			f.index, f.seq = index, seq
+			f.mtx.Unlock()
+			return nil
+		default:
+			return fmt.Errorf("%w: kind %d in snapshot", ErrUnexpectedFrame, fr.Kind)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+// Package replication keeps read replicas of a RoarIndex up to date by
+// shipping its mutation log.
+//
+// All writes go through a Primary, which applies them to its index and
+// appends them to a bounded log with monotonically increasing sequence
+// numbers. A Follower connects over any io.ReadWriter, typically a
+// net.Conn, sends the sequence number of the last entry it applied and
+// receives the entries after it. When those entries are no longer in the
+// log, the Primary first sends a snapshot of its index.
+//
+// Pushes and deletes are idempotent, so a snapshot may already contain some
+// of the entries that follow it without changing the outcome; this lets the
+// Primary take snapshots without stopping writers. A snapshot is streamed
+// as chunks of keys with their values, each read under its own read lock,
+// so a slow follower neither holds up writes nor makes the Primary buffer
+// the whole index.
+package replication
+
+import (
+	"bufio"
+	"encoding/gob"
+	"io"
+	"net"
+	"slices"
+	"sync"
+
+	"github.com/thisisdevelopment/roarindex"
+)
+
+// DefaultLogSize is the number of entries a Primary keeps for followers to
+// resume from when Options.LogSize is 0.
+const DefaultLogSize = 64 * 1024
+
+// maxFrameEntries bounds the number of entries, or of snapshot keys, sent
+// per frame.
+const maxFrameEntries = 1024
+
+// Options configures a Primary. The zero value is ready to use.
+type Options struct {
+	// LogSize is the number of entries kept for followers to resume from,
+	// DefaultLogSize when 0. Followers further behind get a snapshot.
+	LogSize int
+}
+
+var _ roarindex.Index[string, string] = (*Primary[string, string])(nil)
+
+// Primary is a roarindex.Index that records its mutations in a log and
+// streams them to followers. The wrapped index must only be written through
+// the Primary.
+type Primary[K comparable, V comparable] struct {
+	index   *roarindex.RoarIndex[K, V]
+	logSize int
+
+	// Serializes writes, so the log has the order they were applied in.
+	// Held across calls into the index, unlike mtx.
+	writeMtx sync.Mutex
+
+	mtx  sync.Mutex
+	cond *sync.Cond
+	seq  uint64
+	log  []Entry[K, V]
+
+	listeners map[net.Listener]struct{}
+	conns     map[io.ReadWriter]struct{}
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+// NewPrimary creates a new Primary for index. Sequence numbers start at 1.
+func NewPrimary[K comparable, V comparable](index *roarindex.RoarIndex[K, V], opts *Options) *Primary[K, V] {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	p := &Primary[K, V]{
+		index:     index,
+		logSize:   opts.LogSize,
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[io.ReadWriter]struct{}),
+	}
+	if p.logSize <= 0 {
+		p.logSize = DefaultLogSize
+	}
+	p.cond = sync.NewCond(&p.mtx)
+	return p
+}
+
+// Seq returns the sequence number of the last mutation.
+func (p *Primary[K, V]) Seq() uint64 {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.seq
+}
+
+// PushMap implements roarindex.Index.
+func (p *Primary[K, V]) PushMap(key K, value V) {
+	p.writeMtx.Lock()
+	defer p.writeMtx.Unlock()
+
+	p.index.PushMap(key, value)
+	p.record(Entry[K, V]{Op: OpPush, Key: key, Value: value})
+}
+
+// DeleteMap implements roarindex.Index.
+func (p *Primary[K, V]) DeleteMap(key K) {
+	p.writeMtx.Lock()
+	defer p.writeMtx.Unlock()
+
+	p.index.DeleteMap(key)
+	p.record(Entry[K, V]{Op: OpDelete, Key: key})
+}
+
+// GetMap implements roarindex.Index.
+func (p *Primary[K, V]) GetMap(key K) ([]V, error) {
+	return p.index.GetMap(key)
+}
+
+// HasValue implements roarindex.Index.
+func (p *Primary[K, V]) HasValue(key K, value V) bool {
+	return p.index.HasValue(key, value)
+}
+
+// Keys implements roarindex.Index.
+func (p *Primary[K, V]) Keys() []K {
+	return p.index.Keys()
+}
+
+// Values implements roarindex.Index.
+func (p *Primary[K, V]) Values() []V {
+	return p.index.Values()
+}
+
+// Count implements roarindex.Index.
+func (p *Primary[K, V]) Count() int {
+	return p.index.Count()
+}
+
+// record assigns the next sequence number to entry, adds it to the log and
+// wakes up the followers.
+func (p *Primary[K, V]) record(entry Entry[K, V]) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.seq++
+	entry.Seq = p.seq
+	p.log = append(p.log, entry)
+
+	// Let the log grow to twice its size before dropping the oldest half,
+	// so trimming is amortized
+	if len(p.log) >= 2*p.logSize {
+		p.log = append([]Entry[K, V](nil), p.log[len(p.log)-p.logSize:]...)
+	}
+	p.cond.Broadcast()
+}
+
+// entriesAfterLocked returns up to maxFrameEntries entries following seq
+// from, and false when they are no longer in the log.
+func (p *Primary[K, V]) entriesAfterLocked(from uint64) ([]Entry[K, V], bool) {
+	first := p.seq - uint64(len(p.log)) + 1
+	if from+1 < first || from > p.seq {
+		return nil, false
+	}
+
+	start := int(from + 1 - first)
+	end := min(len(p.log), start+maxFrameEntries)
+	return append([]Entry[K, V](nil), p.log[start:end]...), true
+}
+
+// WriteSnapshot writes a snapshot of the index to w, as read by
+// roarindex.ReadRoarIndex, and returns the sequence number to pass to
+// NewFollower along with the index read from it. Writes wait until the
+// snapshot is written, so w should be fast, such as a local file.
+func (p *Primary[K, V]) WriteSnapshot(w io.Writer) (uint64, error) {
+	seq := p.Seq()
+	if _, err := p.index.WriteTo(w); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// Serve accepts followers on l until the Primary is closed. It always
+// returns a non-nil error, net.ErrClosed after Close.
+func (p *Primary[K, V]) Serve(l net.Listener) error {
+	p.mtx.Lock()
+	if p.closed {
+		p.mtx.Unlock()
+		l.Close()
+		return net.ErrClosed
+	}
+	p.listeners[l] = struct{}{}
+	p.mtx.Unlock()
+
+	defer func() {
+		p.mtx.Lock()
+		delete(p.listeners, l)
+		p.mtx.Unlock()
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			p.ServeConn(conn)
+		}()
+	}
+}
+
+// ServeConn streams the log to the follower connected through rw until
+// writing fails or the Primary is closed, in which case it returns nil.
+// rw is closed by Close when it implements io.Closer.
+func (p *Primary[K, V]) ServeConn(rw io.ReadWriter) error {
+	p.mtx.Lock()
+	if p.closed {
+		p.mtx.Unlock()
+		return nil
+	}
+	p.conns[rw] = struct{}{}
+	p.wg.Add(1)
+	p.mtx.Unlock()
+
+	defer func() {
+		p.mtx.Lock()
+		delete(p.conns, rw)
+		p.mtx.Unlock()
+		p.wg.Done()
+	}()
+
+	var req request
+	if err := gob.NewDecoder(rw).Decode(&req); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(rw)
+	enc := gob.NewEncoder(bw)
+	sent := req.From
+	for {
+		p.mtx.Lock()
+		for !p.closed && sent == p.seq {
+			p.cond.Wait()
+		}
+		if p.closed {
+			p.mtx.Unlock()
+			return nil
+		}
+		entries, ok := p.entriesAfterLocked(sent)
+		p.mtx.Unlock()
+
+		if !ok {
+			seq, err := p.sendSnapshot(enc)
+			if err != nil {
+				return p.connErr(err)
+			}
+			sent = seq
+		} else {
+			if err := enc.Encode(frame[K, V]{Kind: frameEntries, Entries: entries}); err != nil {
+				return p.connErr(err)
+			}
+			sent = entries[len(entries)-1].Seq
+		}
+
+		if err := bw.Flush(); err != nil {
+			return p.connErr(err)
+		}
+	}
+}
+
+// sendSnapshot sends a snapshot of the index and returns the sequence
+// number the log continues after. Values are read a chunk of keys at a
+// time, so the lock of the index is not held while sending. Keys pushed
+// after the sequence number was read are in the log instead.
+func (p *Primary[K, V]) sendSnapshot(enc *gob.Encoder) (uint64, error) {
+	seq := p.Seq()
+	keys := p.index.Keys()
+	if err := enc.Encode(frame[K, V]{Kind: frameSnapshotStart, Seq: seq}); err != nil {
+		return 0, err
+	}
+
+	for chunk := range slices.Chunk(keys, maxFrameEntries) {
+		// Keys deleted in the meantime are missing, and so is their delete
+		// from the log the follower replays
+		values, _ := p.index.GetMaps(chunk)
+		pairs := make([]snapshotKey[K, V], 0, len(values))
+		for _, key := range chunk {
+			if keyValues, exists := values[key]; exists {
+				pairs = append(pairs, snapshotKey[K, V]{Key: key, Values: keyValues})
+			}
+		}
+		if err := enc.Encode(frame[K, V]{Kind: frameSnapshotData, Keys: pairs}); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := enc.Encode(frame[K, V]{Kind: frameSnapshotEnd, Seq: seq}); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// connErr hides the error caused by Close closing the connection.
+func (p *Primary[K, V]) connErr(err error) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	return err
+}
+
+// Close stops all listeners, ends all follower connections and waits for
+// them to return. The index stays usable.
+func (p *Primary[K, V]) Close() error {
+	p.mtx.Lock()
+	p.closed = true
+	for l := range p.listeners {
+		l.Close()
+	}
+	for rw := range p.conns {
+		if c, ok := rw.(io.Closer); ok {
+			c.Close()
+		}
+	}
+	p.cond.Broadcast()
+	p.mtx.Unlock()
+
+	p.wg.Wait()
+	return nil
+}
@@ -0,0 +1,53 @@
+package replication
+
+// Op is the kind of mutation an Entry records.
+type Op uint8
+
+const (
+	// OpPush is a PushMap of Key and Value.
+	OpPush Op = iota + 1
+	// OpDelete is a DeleteMap of Key.
+	OpDelete
+)
+
+// Entry is a single mutation in the log of a Primary.
+type Entry[K comparable, V comparable] struct {
+	Seq   uint64
+	Op    Op
+	Key   K
+	Value V
+}
+
+// request is sent by a follower when it connects.
+type request struct {
+	// From is the sequence number of the last entry the follower applied.
+	From uint64
+}
+
+type frameKind uint8
+
+const (
+	// frameEntries carries log entries in sequence order.
+	frameEntries frameKind = iota + 1
+	// frameSnapshotStart announces a snapshot of the index as of Seq, the
+	// log continues after Seq once it ends.
+	frameSnapshotStart
+	// frameSnapshotData carries the next keys of the snapshot.
+	frameSnapshotData
+	// frameSnapshotEnd ends the snapshot.
+	frameSnapshotEnd
+)
+
+// frame is the unit a primary sends to its followers, gob encoded.
+type frame[K comparable, V comparable] struct {
+	Kind    frameKind
+	Seq     uint64
+	Entries []Entry[K, V]
+	Keys    []snapshotKey[K, V]
+}
+
+// snapshotKey is a key of a snapshot with its values.
+type snapshotKey[K comparable, V comparable] struct {
+	Key    K
+	Values []V
+}
@@ -0,0 +1,307 @@
+package replication
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/thisisdevelopment/roarindex"
+)
+
+// follow connects f to p through an in-process pipe and returns a channel
+// receiving the result of Follow and the follower end of the pipe.
+func follow(p *Primary[string, int], f *Follower[string, int]) (<-chan error, net.Conn) {
+	primaryConn, followerConn := net.Pipe()
+	go func() {
+		defer primaryConn.Close()
+		p.ServeConn(primaryConn)
+	}()
+
+	done := make(chan error, 1)
+	go func() { done <- f.Follow(followerConn) }()
+	return done, followerConn
+}
+
+// waitForSeq waits until f applied the entries up to seq.
+func waitForSeq(t *testing.T, f *Follower[string, int], seq uint64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for f.Seq() != seq {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected follower at sequence %d, but it is at %d", seq, f.Seq())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// assertReplicated checks that f holds the same pairs as p.
+func assertReplicated(t *testing.T, p *Primary[string, int], f *Follower[string, int]) {
+	t.Helper()
+	keys := p.Keys()
+	slices.Sort(keys)
+	followerKeys := f.Keys()
+	slices.Sort(followerKeys)
+	if !reflect.DeepEqual(keys, followerKeys) {
+		t.Fatalf("Expected keys %v, but got %v", keys, followerKeys)
+	}
+
+	for _, key := range keys {
+		values, _ := p.GetMap(key)
+		slices.Sort(values)
+		followerValues, err := f.GetMap(key)
+		if err != nil {
+			t.Fatalf("GetMap(%q) on follower failed: %v", key, err)
+		}
+		slices.Sort(followerValues)
+		if !reflect.DeepEqual(values, followerValues) {
+			t.Errorf("Expected %v for %q, but got %v", values, key, followerValues)
+		}
+	}
+}
+
+func TestReplicationStream(t *testing.T) {
+	p := NewPrimary(roarindex.NewRoarIndex[string, int](), nil)
+	defer p.Close()
+	for i := 0; i < 100; i++ {
+		p.PushMap(fmt.Sprintf("key%d", i%10), i)
+	}
+
+	f := NewFollower(roarindex.NewRoarIndex[string, int](), 0)
+	done, _ := follow(p, f)
+
+	waitForSeq(t, f, 100)
+	assertReplicated(t, p, f)
+
+	p.DeleteMap("key3")
+	p.PushMap("key3", 1000)
+	p.DeleteMap("key4")
+	waitForSeq(t, f, 103)
+	assertReplicated(t, p, f)
+	if f.HasValue("key3", 3) || !f.HasValue("key3", 1000) {
+		t.Errorf("Expected key3 to only hold 1000")
+	}
+
+	p.Close()
+	if err := <-done; err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF once the primary is gone, but got %v", err)
+	}
+}
+
+func TestReplicationResume(t *testing.T) {
+	p := NewPrimary(roarindex.NewRoarIndex[string, int](), nil)
+	defer p.Close()
+	p.PushMap("key1", 1)
+
+	index := roarindex.NewRoarIndex[string, int]()
+	f := NewFollower(index, 0)
+	done, conn := follow(p, f)
+	waitForSeq(t, f, 1)
+
+	conn.Close()
+	<-done
+	p.PushMap("key1", 2)
+	p.PushMap("key2", 3)
+
+	follow(p, f)
+	waitForSeq(t, f, 3)
+	assertReplicated(t, p, f)
+	if f.Index() != index {
+		t.Errorf("Expected to resume from the log without a snapshot")
+	}
+}
+
+func TestReplicationSnapshotWhenLogTruncated(t *testing.T) {
+	p := NewPrimary(roarindex.NewRoarIndex[string, int](), &Options{LogSize: 4})
+	defer p.Close()
+	for i := 0; i < 1000; i++ {
+		p.PushMap(fmt.Sprintf("key%d", i%7), i)
+	}
+	p.DeleteMap("key0")
+
+	index := roarindex.NewRoarIndex[string, int]()
+	f := NewFollower(index, 0)
+	follow(p, f)
+
+	waitForSeq(t, f, 1001)
+	assertReplicated(t, p, f)
+	if f.Index() == index {
+		t.Errorf("Expected the follower to bootstrap from a snapshot")
+	}
+
+	p.PushMap("key0", 1)
+	waitForSeq(t, f, 1002)
+	assertReplicated(t, p, f)
+}
+
+func TestReplicationSnapshotDuringWrites(t *testing.T) {
+	p := NewPrimary(roarindex.NewRoarIndex[string, int](), &Options{LogSize: 1000})
+	defer p.Close()
+	for i := 0; i < 5000; i++ {
+		p.PushMap(fmt.Sprintf("key%d", i%500), i)
+	}
+
+	// The snapshot is read a chunk at a time while keys change under it
+	f := NewFollower(roarindex.NewRoarIndex[string, int](), 0)
+	follow(p, f)
+	for i := 0; i < 2000; i++ {
+		key := fmt.Sprintf("key%d", i%600)
+		if i%3 == 0 {
+			p.DeleteMap(key)
+		} else {
+			p.PushMap(key, -i)
+		}
+	}
+
+	waitForSeq(t, f, p.Seq())
+	assertReplicated(t, p, f)
+}
+
+func TestReplicationBootstrapFromSnapshot(t *testing.T) {
+	p := NewPrimary(roarindex.NewRoarIndex[string, int](), nil)
+	defer p.Close()
+	for i := 0; i < 50; i++ {
+		p.PushMap(fmt.Sprintf("key%d", i%5), i)
+	}
+
+	var buf bytes.Buffer
+	seq, err := p.WriteSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+	p.PushMap("key5", 50)
+
+	index, err := roarindex.ReadRoarIndex[string, int](&buf)
+	if err != nil {
+		t.Fatalf("ReadRoarIndex failed: %v", err)
+	}
+	f := NewFollower(index, seq)
+	follow(p, f)
+
+	waitForSeq(t, f, 51)
+	assertReplicated(t, p, f)
+	if f.Index() != index {
+		t.Errorf("Expected to continue from the snapshot without a new one")
+	}
+}
+
+func TestReplicationFollowerAhead(t *testing.T) {
+	p := NewPrimary(roarindex.NewRoarIndex[string, int](), nil)
+	defer p.Close()
+	p.PushMap("key1", 1)
+
+	stale := roarindex.NewRoarIndex[string, int]()
+	stale.PushMap("stale", 1)
+	f := NewFollower(stale, 10)
+	follow(p, f)
+
+	waitForSeq(t, f, 1)
+	assertReplicated(t, p, f)
+}
+
+func TestReplicationSequenceGap(t *testing.T) {
+	primaryConn, followerConn := net.Pipe()
+	defer primaryConn.Close()
+	go func() {
+		var req request
+		dec := gob.NewDecoder(primaryConn)
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+		enc := gob.NewEncoder(primaryConn)
+		enc.Encode(frame[string, int]{Kind: frameEntries, Entries: []Entry[string, int]{
+			{Seq: 1, Op: OpPush, Key: "key1", Value: 1},
+			{Seq: 3, Op: OpPush, Key: "key1", Value: 3},
+		}})
+	}()
+
+	f := NewFollower(roarindex.NewRoarIndex[string, int](), 0)
+	if err := f.Follow(followerConn); !errors.Is(err, ErrSequenceGap) {
+		t.Errorf("Expected ErrSequenceGap, but got %v", err)
+	}
+	if f.Seq() != 1 || !f.HasValue("key1", 1) {
+		t.Errorf("Expected the entries before the gap to be applied")
+	}
+}
+
+func TestReplicationServe(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("Cannot listen: %v", err)
+	}
+
+	p := NewPrimary(roarindex.NewRoarIndex[string, int](), nil)
+	served := make(chan error, 1)
+	go func() { served <- p.Serve(l) }()
+	p.PushMap("key1", 1)
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	f := NewFollower(roarindex.NewRoarIndex[string, int](), 0)
+	done := make(chan error, 1)
+	go func() { done <- f.Follow(conn) }()
+	waitForSeq(t, f, 1)
+
+	p.Close()
+	if err := <-served; !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Expected net.ErrClosed, but got %v", err)
+	}
+	if err := <-done; err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF once the primary is gone, but got %v", err)
+	}
+}
+
+func TestReplicationStalledFollower(t *testing.T) {
+	// A snapshot far larger than the buffers between the index and the pipe
+	p := NewPrimary(roarindex.NewRoarIndex[string, int](), &Options{LogSize: 4})
+	for i := 0; i < 50000; i++ {
+		p.PushMap(fmt.Sprintf("key%d", i%5000), i)
+	}
+
+	// The follower asks for a snapshot and never reads it
+	primaryConn, followerConn := net.Pipe()
+	defer followerConn.Close()
+	served := make(chan error, 1)
+	go func() { served <- p.ServeConn(primaryConn) }()
+	if err := gob.NewEncoder(followerConn).Encode(request{From: 0}); err != nil {
+		t.Fatalf("Sending the request failed: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	// within fails the test when fn does not return in time
+	within := func(name string, fn func()) {
+		t.Helper()
+		done := make(chan struct{})
+		go func() {
+			fn()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected %s not to block on a stalled follower", name)
+		}
+	}
+
+	within("PushMap", func() { p.PushMap("key1", 1000) })
+	within("DeleteMap", func() { p.DeleteMap("key2") })
+	within("WriteSnapshot", func() {
+		if _, err := p.WriteSnapshot(io.Discard); err != nil {
+			t.Errorf("WriteSnapshot failed: %v", err)
+		}
+	})
+	within("Close", func() { p.Close() })
+	if err := <-served; err != nil {
+		t.Errorf("Expected ServeConn to end cleanly, but got %v", err)
+	}
+}
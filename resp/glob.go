@@ -0,0 +1,84 @@
+package resp
+
+// matchGlob reports whether s matches pattern the way Redis matches KEYS
+// patterns: * matches any run of bytes, ? any single byte, [abc], [a-z] and
+// [^abc] a byte in or not in a class, and \ escapes the next byte. Unlike
+// path.Match, / is an ordinary byte.
+func matchGlob(pattern, s string) bool {
+	px, sx := 0, 0
+	// Where to resume after the last *, letting it match one more byte
+	starPx, starSx := -1, 0
+	for px < len(pattern) || sx < len(s) {
+		if px < len(pattern) {
+			switch c := pattern[px]; c {
+			case '*':
+				starPx, starSx = px, sx+1
+				px++
+				continue
+			case '?':
+				if sx < len(s) {
+					px++
+					sx++
+					continue
+				}
+			case '[':
+				if sx < len(s) {
+					if matched, end := matchClass(pattern, px+1, s[sx]); matched {
+						px = end
+						sx++
+						continue
+					}
+				}
+			default:
+				width := 1
+				if c == '\\' && px+1 < len(pattern) {
+					c, width = pattern[px+1], 2
+				}
+				if sx < len(s) && s[sx] == c {
+					px += width
+					sx++
+					continue
+				}
+			}
+		}
+		if starPx >= 0 && starSx <= len(s) {
+			px, sx = starPx, starSx
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// matchClass matches c against the class starting at pattern[i], just
+// after its [, and returns the index after its closing ]. An unterminated
+// class extends to the end of the pattern, as in Redis.
+func matchClass(pattern string, i int, c byte) (bool, int) {
+	negate := i < len(pattern) && pattern[i] == '^'
+	if negate {
+		i++
+	}
+
+	matched := false
+	for i < len(pattern) && pattern[i] != ']' {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			matched = matched || pattern[i+1] == c
+			i += 2
+		case i+2 < len(pattern) && pattern[i+1] == '-':
+			lo, hi := pattern[i], pattern[i+2]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			matched = matched || (lo <= c && c <= hi)
+			i += 3
+		default:
+			matched = matched || pattern[i] == c
+			i++
+		}
+	}
+	if i < len(pattern) {
+		i++
+	}
+	return matched != negate, i
+}
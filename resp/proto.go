@@ -0,0 +1,158 @@
+package resp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrProtocol is returned when a client sends malformed RESP.
+var ErrProtocol = errors.New("protocol error")
+
+// maxBulkLen bounds the size of a single bulk string a client may send.
+const maxBulkLen = 512 * 1024 * 1024
+
+// maxArrayLen bounds the number of arguments of a single command.
+const maxArrayLen = 1024 * 1024
+
+// maxLineLen bounds the length of an inline command or of a RESP header
+// line.
+const maxLineLen = 64 * 1024
+
+// readCommand reads a command, either as an array of bulk strings or as an
+// inline command separated by spaces.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		return strings.Fields(line), nil
+	}
+
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n > maxArrayLen {
+		return nil, fmt.Errorf("%w: invalid multibulk length", ErrProtocol)
+	}
+
+	args := make([]string, 0, max(n, 0))
+	for i := 0; i < n; i++ {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, fmt.Errorf("%w: expected '$', got %q", ErrProtocol, line)
+		}
+
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 || size > maxBulkLen {
+			return nil, fmt.Errorf("%w: invalid bulk length", ErrProtocol)
+		}
+
+		// The buffer grows as the payload arrives rather than by the
+		// announced length, so a client can't claim memory it never sends
+		var buf bytes.Buffer
+		if n, err := io.CopyN(&buf, r, int64(size)+2); err != nil {
+			if err == io.EOF && n > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		bulk := buf.Bytes()
+		if bulk[size] != '\r' || bulk[size+1] != '\n' {
+			return nil, fmt.Errorf("%w: bulk string not terminated", ErrProtocol)
+		}
+		args = append(args, string(bulk[:size]))
+	}
+	return args, nil
+}
+
+// readLine reads a line terminated by CRLF or LF, without the terminator.
+// Lines longer than maxLineLen are a protocol error.
+func readLine(r *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		if len(line)+len(chunk) > maxLineLen {
+			return "", fmt.Errorf("%w: line too long", ErrProtocol)
+		}
+		line = append(line, chunk...)
+		if err == nil {
+			break
+		}
+		if err != bufio.ErrBufferFull {
+			return "", err
+		}
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r"), nil
+}
+
+// writer writes RESP2 or RESP3 replies.
+type writer struct {
+	*bufio.Writer
+	protocol int
+}
+
+func (w *writer) simple(s string) {
+	w.WriteString("+" + s + "\r\n")
+}
+
+func (w *writer) error(msg string) {
+	w.WriteString("-" + msg + "\r\n")
+}
+
+func (w *writer) integer(n int) {
+	w.WriteString(":" + strconv.Itoa(n) + "\r\n")
+}
+
+func (w *writer) bulk(s string) {
+	w.WriteString("$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n")
+}
+
+func (w *writer) null() {
+	if w.protocol >= 3 {
+		w.WriteString("_\r\n")
+		return
+	}
+	w.WriteString("$-1\r\n")
+}
+
+func (w *writer) array(items []string) {
+	w.aggregate('*', len(items))
+	for _, item := range items {
+		w.bulk(item)
+	}
+}
+
+// set writes a set in RESP3 and an array in RESP2.
+func (w *writer) set(items []string) {
+	if w.protocol < 3 {
+		w.array(items)
+		return
+	}
+	w.aggregate('~', len(items))
+	for _, item := range items {
+		w.bulk(item)
+	}
+}
+
+// aggregate writes the header of an array, set or map.
+func (w *writer) aggregate(kind byte, n int) {
+	w.WriteByte(kind)
+	w.WriteString(strconv.Itoa(n) + "\r\n")
+}
+
+// mapHeader writes the header of a map in RESP3 and of a flat array of
+// keys and values in RESP2.
+func (w *writer) mapHeader(n int) {
+	if w.protocol < 3 {
+		w.aggregate('*', n*2)
+		return
+	}
+	w.aggregate('%', n)
+}
@@ -0,0 +1,270 @@
+// Package resp serves a RoarIndex over the Redis serialization protocol
+// (RESP2 and RESP3), so redis-cli and Redis client libraries can use it as
+// a store of sets.
+//
+// Supported commands:
+//
+//	SADD key member [member ...]    PushMap
+//	SMEMBERS key                    GetMap
+//	SISMEMBER key member            HasValue
+//	SCARD key                       number of values of a key
+//	DEL key [key ...]               DeleteMap
+//	SINTER key [key ...]            Intersect
+//	SUNION key [key ...]            Union
+//	SDIFF key [key ...]             Difference
+//	KEYS pattern                    Keys matching a glob pattern
+//	DBSIZE                          Count
+//	PING, ECHO, HELLO, COMMAND, QUIT
+package resp
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/thisisdevelopment/roarindex"
+)
+
+// errQuit ends a connection after the reply to QUIT was written.
+var errQuit = errors.New("quit")
+
+// Server serves a RoarIndex to RESP clients.
+type Server struct {
+	index *roarindex.RoarIndex[string, string]
+
+	mtx       sync.Mutex
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+// NewServer creates a new Server for index.
+func NewServer(index *roarindex.RoarIndex[string, string]) *Server {
+	return &Server{
+		index:     index,
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+}
+
+// ListenAndServe listens on the TCP address addr and serves clients.
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve accepts clients on l until the Server is closed. It always returns
+// a non-nil error, net.ErrClosed after Close.
+func (s *Server) Serve(l net.Listener) error {
+	s.mtx.Lock()
+	if s.closed {
+		s.mtx.Unlock()
+		l.Close()
+		return net.ErrClosed
+	}
+	s.listeners[l] = struct{}{}
+	s.mtx.Unlock()
+
+	defer func() {
+		s.mtx.Lock()
+		delete(s.listeners, l)
+		s.mtx.Unlock()
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+
+		s.mtx.Lock()
+		if s.closed {
+			s.mtx.Unlock()
+			conn.Close()
+			return net.ErrClosed
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mtx.Unlock()
+
+		go s.serveConn(conn)
+	}
+}
+
+// Close stops all listeners, closes all client connections and waits for
+// their handlers to return.
+func (s *Server) Close() error {
+	s.mtx.Lock()
+	s.closed = true
+	for l := range s.listeners {
+		l.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mtx.Unlock()
+
+	s.wg.Wait()
+	return nil
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer func() {
+		conn.Close()
+		s.mtx.Lock()
+		delete(s.conns, conn)
+		s.mtx.Unlock()
+		s.wg.Done()
+	}()
+
+	r := bufio.NewReader(conn)
+	w := &writer{Writer: bufio.NewWriter(conn), protocol: 2}
+
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			if errors.Is(err, ErrProtocol) {
+				w.error("ERR " + err.Error())
+				w.Flush()
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		err = s.execute(w, args)
+		// Only flush once no more pipelined commands are buffered
+		if r.Buffered() == 0 || err != nil {
+			if flushErr := w.Flush(); flushErr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// execute runs a single command and writes its reply.
+func (s *Server) execute(w *writer, args []string) error {
+	name, args := strings.ToUpper(args[0]), args[1:]
+
+	bounds, known := commandArity[name]
+	if !known {
+		w.error("ERR unknown command '" + truncate(strings.ToLower(name)) + "'")
+		return nil
+	}
+	if len(args) < bounds.min || (bounds.max >= 0 && len(args) > bounds.max) {
+		w.error("ERR wrong number of arguments for '" + strings.ToLower(name) + "' command")
+		return nil
+	}
+
+	switch name {
+	case "PING":
+		if len(args) > 0 {
+			w.bulk(args[0])
+		} else {
+			w.simple("PONG")
+		}
+	case "ECHO":
+		w.bulk(args[0])
+	case "QUIT":
+		w.simple("OK")
+		return errQuit
+	case "HELLO":
+		s.hello(w, args)
+	case "COMMAND":
+		w.array(nil)
+	case "SADD":
+		w.integer(s.index.PushValues(args[0], args[1:]...))
+	case "SMEMBERS":
+		values, _ := s.index.GetMap(args[0])
+		w.set(values)
+	case "SISMEMBER":
+		if s.index.HasValue(args[0], args[1]) {
+			w.integer(1)
+		} else {
+			w.integer(0)
+		}
+	case "SCARD":
+		w.integer(s.index.Cardinality(args[0]))
+	case "DEL":
+		w.integer(s.index.DeleteMaps(args...))
+	case "SINTER":
+		w.set(s.index.Intersect(args...))
+	case "SUNION":
+		w.set(s.index.Union(args...))
+	case "SDIFF":
+		w.set(s.index.Difference(args[0], args[1:]...))
+	case "KEYS":
+		keys := slices.DeleteFunc(s.index.Keys(), func(key string) bool {
+			return !matchGlob(args[0], key)
+		})
+		slices.Sort(keys)
+		w.array(keys)
+	case "DBSIZE":
+		w.integer(s.index.Count())
+	}
+	return nil
+}
+
+// hello switches the protocol version and describes the server.
+func (s *Server) hello(w *writer, args []string) {
+	if len(args) > 0 {
+		protocol, err := strconv.Atoi(args[0])
+		if err != nil || protocol < 2 || protocol > 3 {
+			w.error("NOPROTO unsupported protocol version")
+			return
+		}
+		w.protocol = protocol
+	}
+
+	w.mapHeader(3)
+	w.bulk("server")
+	w.bulk("roarindex")
+	w.bulk("proto")
+	w.integer(w.protocol)
+	w.bulk("mode")
+	w.bulk("standalone")
+}
+
+// arity is the number of arguments a command accepts, max is -1 when
+// there is no upper bound.
+type arity struct {
+	min, max int
+}
+
+var commandArity = map[string]arity{
+	"PING":      {0, 1},
+	"ECHO":      {1, 1},
+	"QUIT":      {0, 0},
+	"HELLO":     {0, -1},
+	"COMMAND":   {0, -1},
+	"SADD":      {2, -1},
+	"SMEMBERS":  {1, 1},
+	"SISMEMBER": {2, 2},
+	"SCARD":     {1, 1},
+	"DEL":       {1, -1},
+	"SINTER":    {1, -1},
+	"SUNION":    {1, -1},
+	"SDIFF":     {1, -1},
+	"KEYS":      {1, 1},
+	"DBSIZE":    {0, 0},
+}
+
+// truncate keeps error messages about unknown commands readable.
+func truncate(s string) string {
+	if len(s) > 64 {
+		return s[:64] + "..."
+	}
+	return s
+}
@@ -0,0 +1,389 @@
+package resp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/thisisdevelopment/roarindex"
+)
+
+// client is a minimal RESP client for testing.
+type client struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func startServer(t *testing.T) (*Server, *client) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+
+	s := NewServer(roarindex.NewRoarIndex[string, string]())
+	go s.Serve(l)
+	t.Cleanup(func() { s.Close() })
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	return s, &client{conn: conn, r: bufio.NewReader(conn)}
+}
+
+// do sends a command and reads its reply.
+func (c *client) do(t *testing.T, args ...string) any {
+	t.Helper()
+	msg := fmt.Sprintf("*%d\r\n", len(args))
+	for _, arg := range args {
+		msg += fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := c.conn.Write([]byte(msg)); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	reply, err := c.read()
+	if err != nil {
+		t.Fatalf("Reading reply to %v failed: %v", args, err)
+	}
+	return reply
+}
+
+// read reads a reply: strings for simple and bulk strings, errors, ints,
+// nil, []any for arrays and sets and map[string]any for maps.
+func (c *client) read() (any, error) {
+	line, err := readLine(c.r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, ErrProtocol
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return errors.New(line[1:]), nil
+	case ':':
+		return strconv.Atoi(line[1:])
+	case '_':
+		return nil, nil
+	case '$':
+		n, _ := strconv.Atoi(line[1:])
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		_, err := io.ReadFull(c.r, buf)
+		return string(buf[:n]), err
+	case '*', '~':
+		n, _ := strconv.Atoi(line[1:])
+		items := make([]any, 0, n)
+		for i := 0; i < n; i++ {
+			item, err := c.read()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case '%':
+		n, _ := strconv.Atoi(line[1:])
+		m := make(map[string]any, n)
+		for i := 0; i < n; i++ {
+			key, err := c.read()
+			if err != nil {
+				return nil, err
+			}
+			value, err := c.read()
+			if err != nil {
+				return nil, err
+			}
+			m[key.(string)] = value
+		}
+		return m, nil
+	}
+	return nil, ErrProtocol
+}
+
+func sortedStrings(reply any) []string {
+	items, _ := reply.([]any)
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.(string))
+	}
+	slices.Sort(result)
+	return result
+}
+
+func TestServerSetCommands(t *testing.T) {
+	_, c := startServer(t)
+
+	if reply := c.do(t, "SADD", "key1", "a", "b", "c"); reply != 3 {
+		t.Errorf("Expected SADD to add 3 members, got %v", reply)
+	}
+	if reply := c.do(t, "SADD", "key1", "c", "d"); reply != 1 {
+		t.Errorf("Expected SADD to add 1 member, got %v", reply)
+	}
+	c.do(t, "sadd", "key2", "c", "d", "e")
+
+	if reply := sortedStrings(c.do(t, "SMEMBERS", "key1")); !reflect.DeepEqual(reply, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Expected the members of key1, got %v", reply)
+	}
+	if reply := c.do(t, "SMEMBERS", "nonExistent"); !reflect.DeepEqual(reply, []any{}) {
+		t.Errorf("Expected an empty set for a missing key, got %v", reply)
+	}
+	if reply := c.do(t, "SISMEMBER", "key1", "a"); reply != 1 {
+		t.Errorf("Expected a to be a member of key1, got %v", reply)
+	}
+	if reply := c.do(t, "SISMEMBER", "key1", "e"); reply != 0 {
+		t.Errorf("Expected e not to be a member of key1, got %v", reply)
+	}
+	if reply := c.do(t, "SCARD", "key1"); reply != 4 {
+		t.Errorf("Expected key1 to hold 4 members, got %v", reply)
+	}
+
+	if reply := sortedStrings(c.do(t, "SINTER", "key1", "key2")); !reflect.DeepEqual(reply, []string{"c", "d"}) {
+		t.Errorf("Expected the intersection [c d], got %v", reply)
+	}
+	if reply := sortedStrings(c.do(t, "SUNION", "key1", "key2")); !reflect.DeepEqual(reply, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("Expected the union of key1 and key2, got %v", reply)
+	}
+	if reply := sortedStrings(c.do(t, "SDIFF", "key1", "key2")); !reflect.DeepEqual(reply, []string{"a", "b"}) {
+		t.Errorf("Expected the difference [a b], got %v", reply)
+	}
+
+	if reply := c.do(t, "KEYS", "key*"); !reflect.DeepEqual(reply, []any{"key1", "key2"}) {
+		t.Errorf("Expected both keys, got %v", reply)
+	}
+	if reply := c.do(t, "DBSIZE"); reply != 2 {
+		t.Errorf("Expected 2 keys, got %v", reply)
+	}
+	if reply := c.do(t, "DEL", "key1", "nonExistent"); reply != 1 {
+		t.Errorf("Expected DEL to delete 1 key, got %v", reply)
+	}
+	if reply := c.do(t, "DBSIZE"); reply != 1 {
+		t.Errorf("Expected 1 key, got %v", reply)
+	}
+}
+
+func TestServerKeysPattern(t *testing.T) {
+	_, c := startServer(t)
+	for _, key := range []string{"a/b", "a/b/c", "ab", "a*b", "tenant:42"} {
+		c.do(t, "SADD", key, "x")
+	}
+
+	tests := []struct {
+		pattern  string
+		expected []any
+	}{
+		{"*", []any{"a*b", "a/b", "a/b/c", "ab", "tenant:42"}},
+		{"a*", []any{"a*b", "a/b", "a/b/c", "ab"}},
+		{"a/*", []any{"a/b", "a/b/c"}},
+		{"a?b", []any{"a*b", "a/b"}},
+		{`a\*b`, []any{"a*b"}},
+		{"tenant:[0-9]2", []any{"tenant:42"}},
+	}
+	for _, tt := range tests {
+		if reply := c.do(t, "KEYS", tt.pattern); !reflect.DeepEqual(reply, tt.expected) {
+			t.Errorf("Expected %v for %q, got %v", tt.expected, tt.pattern, reply)
+		}
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		expected   bool
+	}{
+		{"", "", true},
+		{"", "a", false},
+		{"*", "", true},
+		{"*", "a/b", true},
+		{"a*c", "abbbc", true},
+		{"a*c", "abbbd", false},
+		{"a**c*", "ac", true},
+		{"*b*b*", "abab", true},
+		{"?", "", false},
+		{"??", "ab", true},
+		{"[abc]", "b", true},
+		{"[abc]", "d", false},
+		{"[^abc]", "d", true},
+		{"[^abc]", "a", false},
+		{"[z-a]", "m", true},
+		{`[\]]`, "]", true},
+		{"[ab", "b", true},
+		{`\*`, "*", true},
+		{`\*`, "a", false},
+		{`a\`, `a\`, true},
+	}
+	for _, tt := range tests {
+		if matched := matchGlob(tt.pattern, tt.s); matched != tt.expected {
+			t.Errorf("Expected matchGlob(%q, %q) to be %v", tt.pattern, tt.s, tt.expected)
+		}
+	}
+}
+
+func TestServerConcurrentCounts(t *testing.T) {
+	s, c := startServer(t)
+	// Once a command was served, the listener is registered
+	c.do(t, "PING")
+	addr := ""
+	s.mtx.Lock()
+	for l := range s.listeners {
+		addr = l.Addr().String()
+	}
+	s.mtx.Unlock()
+
+	// Each member is added by exactly one of the clients racing to add it
+	const clients, members = 8, 100
+	added := make(chan int, clients)
+	for i := 0; i < clients; i++ {
+		go func() {
+			total := 0
+			defer func() { added <- total }()
+			conn, err := net.Dial("tcp", addr)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			c := &client{conn: conn, r: bufio.NewReader(conn)}
+			for j := 0; j < members; j++ {
+				member := strconv.Itoa(j)
+				fmt.Fprintf(conn, "*3\r\n$4\r\nSADD\r\n$3\r\nkey\r\n$%d\r\n%s\r\n", len(member), member)
+				reply, err := c.read()
+				if err != nil {
+					return
+				}
+				if n, ok := reply.(int); ok {
+					total += n
+				}
+			}
+		}()
+	}
+	total := 0
+	for i := 0; i < clients; i++ {
+		total += <-added
+	}
+	if total != members {
+		t.Errorf("Expected SADD to report %d added members in total, got %d", members, total)
+	}
+	if reply := c.do(t, "SCARD", "key"); reply != members {
+		t.Errorf("Expected key to hold %d members, got %v", members, reply)
+	}
+}
+
+func TestServerProtocol(t *testing.T) {
+	_, c := startServer(t)
+
+	if reply := c.do(t, "PING"); reply != "PONG" {
+		t.Errorf("Expected PONG, got %v", reply)
+	}
+	if reply := c.do(t, "ECHO", "hello world"); reply != "hello world" {
+		t.Errorf("Expected the echo, got %v", reply)
+	}
+	if reply, ok := c.do(t, "FLUSHALL").(error); !ok || reply.Error() != "ERR unknown command 'flushall'" {
+		t.Errorf("Expected an unknown command error, got %v", reply)
+	}
+	if _, ok := c.do(t, "SISMEMBER", "key1").(error); !ok {
+		t.Errorf("Expected an arity error")
+	}
+
+	// HELLO 3 switches to RESP3
+	reply, ok := c.do(t, "HELLO", "3").(map[string]any)
+	if !ok || reply["proto"] != 3 {
+		t.Errorf("Expected a RESP3 map, got %v", reply)
+	}
+	c.do(t, "SADD", "key1", "a")
+	c.conn.Write([]byte("*2\r\n$8\r\nSMEMBERS\r\n$4\r\nkey1\r\n"))
+	if line, _ := readLine(c.r); line != "~1" {
+		t.Errorf("Expected a RESP3 set, got %q", line)
+	}
+	readLine(c.r)
+	readLine(c.r)
+	if _, ok := c.do(t, "HELLO", "4").(error); !ok {
+		t.Errorf("Expected HELLO 4 to fail")
+	}
+
+	// Inline commands and pipelining
+	c.conn.Write([]byte("PING\r\nSCARD key1\r\n"))
+	if reply, _ := c.read(); reply != "PONG" {
+		t.Errorf("Expected PONG to the inline PING, got %v", reply)
+	}
+	if reply, _ := c.read(); reply != 1 {
+		t.Errorf("Expected 1 to the inline SCARD, got %v", reply)
+	}
+
+	if reply := c.do(t, "QUIT"); reply != "OK" {
+		t.Errorf("Expected OK, got %v", reply)
+	}
+	if _, err := c.read(); err == nil {
+		t.Errorf("Expected the connection to be closed after QUIT")
+	}
+}
+
+func TestServerMalformedInput(t *testing.T) {
+	_, c := startServer(t)
+
+	c.conn.Write([]byte("*1\r\n+PING\r\n"))
+	reply, _ := c.read()
+	if err, ok := reply.(error); !ok || !strings.HasPrefix(err.Error(), "ERR protocol error") {
+		t.Errorf("Expected a protocol error, got %v", reply)
+	}
+	if _, err := c.read(); err == nil {
+		t.Errorf("Expected the connection to be closed after a protocol error")
+	}
+}
+
+func TestReadCommandLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected error
+	}{
+		{"LongInline", strings.Repeat("a", maxLineLen+1), ErrProtocol},
+		{"LongHeader", "*1\r\n$" + strings.Repeat("1", maxLineLen), ErrProtocol},
+		{"ShortBulk", fmt.Sprintf("*1\r\n$%d\r\nPING", maxBulkLen), io.ErrUnexpectedEOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			_, err := readCommand(bufio.NewReader(strings.NewReader(tt.input)))
+			runtime.ReadMemStats(&after)
+
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, but got %v", tt.expected, err)
+			}
+			// Memory follows the bytes sent, not the lengths announced
+			if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1<<20 {
+				t.Errorf("Expected at most 1MB allocated, but got %d bytes", allocated)
+			}
+		})
+	}
+}
+
+func TestServerClose(t *testing.T) {
+	s, c := startServer(t)
+	c.do(t, "PING")
+
+	s.Close()
+	if _, err := c.read(); err == nil {
+		t.Errorf("Expected the connection to be closed")
+	}
+
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	if err := s.Serve(l); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Expected net.ErrClosed, got %v", err)
+	}
+}
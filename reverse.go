@@ -0,0 +1,73 @@
+package roarindex
+
+import (
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// EnableReverseIndex starts maintaining a mapping from each value to the
+// keys it is associated with, which lets MostSimilar skip keys that share
+// no values. Enabling it again is a no-op.
+func (om *RoarIndex[K, V]) EnableReverseIndex() {
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	if om.reverse != nil {
+		return
+	}
+
+	om.reverse = make(map[uint32]*roaring.Bitmap)
+	om.backend.RangeBitmaps(func(keyID uint32, bm *roaring.Bitmap) bool {
+		it := bm.Iterator()
+		for it.HasNext() {
+			om.reverseAdd(keyID, it.Next())
+		}
+		return true
+	})
+}
+
+// DisableReverseIndex stops maintaining the reverse index and frees it.
+func (om *RoarIndex[K, V]) DisableReverseIndex() {
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	om.reverse = nil
+}
+
+// reverseAdd records that keyID holds valueID. The caller must hold the
+// write lock and have the reverse index enabled.
+func (om *RoarIndex[K, V]) reverseAdd(keyID, valueID uint32) {
+	keys, exists := om.reverse[valueID]
+	if !exists {
+		keys = roaring.NewBitmap()
+		om.reverse[valueID] = keys
+	}
+	keys.Add(keyID)
+}
+
+// reverseRemove drops keyID from the reverse entries of all values in bm.
+// The caller must hold the write lock and have the reverse index enabled.
+func (om *RoarIndex[K, V]) reverseRemove(keyID uint32, bm *roaring.Bitmap) {
+	it := bm.Iterator()
+	for it.HasNext() {
+		valueID := it.Next()
+		if keys, exists := om.reverse[valueID]; exists {
+			keys.Remove(keyID)
+			if keys.IsEmpty() {
+				delete(om.reverse, valueID)
+			}
+		}
+	}
+}
+
+// candidateKeys returns the IDs of all keys sharing at least one value with
+// bm. The caller must hold the lock and have the reverse index enabled.
+func (om *RoarIndex[K, V]) candidateKeys(bm *roaring.Bitmap) *roaring.Bitmap {
+	candidates := roaring.NewBitmap()
+	it := bm.Iterator()
+	for it.HasNext() {
+		if keys, exists := om.reverse[it.Next()]; exists {
+			candidates.Or(keys)
+		}
+	}
+	return candidates
+}
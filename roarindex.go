@@ -1,8 +1,10 @@
 package roarindex
 
 import (
+	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 
 	roaring "github.com/RoaringBitmap/roaring"
 )
@@ -15,29 +17,70 @@ var ErrKeyNotFound = errors.New("key not found")
 type RoarIndex[K comparable, V comparable] struct {
 	mtx sync.RWMutex
 
+	// Unique per index, orders lock acquisition when locking two indexes
+	id uint64
+
 	// Internal counters to assign unique IDs
 	nextKeyID   uint32
 	nextValueID uint32
 
-	// Maps to assign unique IDs to keys and values
-	keyToID   map[K]uint32
-	idToKey   map[uint32]K
-	valueToID map[V]uint32
-	idToValue map[uint32]V
+	// Storage of the key and value dictionaries and the bitmaps of value IDs
+	backend Backend[K, V]
+
+	// Optional map from value IDs to RoaringBitmap of key IDs, nil when disabled
+	reverse map[uint32]*roaring.Bitmap
+
+	// Optional map from key IDs to the value IDs in the order they were
+	// pushed, nil when disabled
+	sequences map[uint32][]uint32
+
+	// Key IDs whose bitmaps changed since the last optimize pass
+	dirty *roaring.Bitmap
 
-	// Map from key IDs to RoaringBitmap of value IDs
-	data map[uint32]*roaring.Bitmap
+	// Secondary indexes on values, told about every new value
+	valueIndexes []valueIndex[V]
+
+	// Secondary indexes on keys, told about every new and deleted key
+	keyIndexes []keyIndex[K]
+
+	// Order of the results of Keys, Values and GetMap, see SetOrder
+	order         Order
+	compareKeys   func(a, b K) int
+	compareValues func(a, b V) int
+
+	// Background optimizer state
+	optimizerStop chan struct{}
+	optimizerDone chan struct{}
 }
 
+// indexIDs hands out the id of each RoarIndex.
+var indexIDs atomic.Uint64
+
 // NewRoarIndex creates a new RoarIndex.
 func NewRoarIndex[K comparable, V comparable]() *RoarIndex[K, V] {
-	return &RoarIndex[K, V]{
-		keyToID:   make(map[K]uint32),
-		idToKey:   make(map[uint32]K),
-		valueToID: make(map[V]uint32),
-		idToValue: make(map[uint32]V),
-		data:      make(map[uint32]*roaring.Bitmap),
+	return NewRoarIndexWithBackend(NewMemoryBackend[K, V]())
+}
+
+// NewRoarIndexWithBackend creates a new RoarIndex stored in backend. A
+// backend that already holds data is picked up as is; new IDs continue
+// after the highest IDs found in it.
+func NewRoarIndexWithBackend[K comparable, V comparable](backend Backend[K, V]) *RoarIndex[K, V] {
+	om := &RoarIndex[K, V]{
+		id:      indexIDs.Add(1),
+		backend: backend,
+		dirty:   roaring.NewBitmap(),
 	}
+
+	backend.RangeKeys(func(_ K, keyID uint32) bool {
+		om.nextKeyID = max(om.nextKeyID, keyID+1)
+		return true
+	})
+	backend.RangeValues(func(_ V, valueID uint32) bool {
+		om.nextValueID = max(om.nextValueID, valueID+1)
+		return true
+	})
+
+	return om
 }
 
 // PushMap associates a value with a key.
@@ -45,61 +88,68 @@ func (om *RoarIndex[K, V]) PushMap(key K, value V) {
 	om.mtx.Lock()
 	defer om.mtx.Unlock()
 
-	// Get or assign key ID
-	keyID, keyExists := om.keyToID[key]
-	if !keyExists {
-		keyID = om.nextKeyID
-		om.nextKeyID++
-		om.keyToID[key] = keyID
-		om.idToKey[keyID] = key
-	}
-
-	// Get or assign value ID
-	valueID, valueExists := om.valueToID[value]
-	if !valueExists {
-		valueID = om.nextValueID
-		om.nextValueID++
-		om.valueToID[value] = valueID
-		om.idToValue[valueID] = value
+	om.pushLocked(key, value)
+}
 
-	}
+// pushLocked adds value to key and reports whether it was not associated
+// with key yet, the caller must hold the write lock.
+func (om *RoarIndex[K, V]) pushLocked(key K, value V) bool {
+	keyID := om.keyIDOrAssign(key)
+	valueID := om.valueIDOrAssign(value)
 
 	// Get or create bitmap for the key
-	bm, exists := om.data[keyID]
+	bm, exists := om.backend.Bitmap(keyID)
 	if !exists {
 		bm = roaring.NewBitmap()
-		om.data[keyID] = bm
 	}
 	// Add the value ID to the bitmap
-	bm.Add(valueID)
-}
-
-// GetMap retrieves the set of values associated with a key.
-func (om *RoarIndex[K, V]) GetMap(key K) ([]V, error) {
-	om.mtx.RLock()
-	defer om.mtx.RUnlock()
-
-	keyID, keyExists := om.keyToID[key]
-	if !keyExists {
-		return nil, ErrKeyNotFound
+	if bm.CheckedAdd(valueID) {
+		om.backend.PutBitmap(keyID, bm)
+		om.dirty.Add(keyID)
+		if om.reverse != nil {
+			om.reverseAdd(keyID, valueID)
+		}
+		if om.sequences != nil {
+			om.sequenceAdd(keyID, valueID)
+		}
+		return true
 	}
+	return false
+}
 
-	bm, exists := om.data[keyID]
+// keyIDOrAssign returns the ID of key, assigning a new one when it is not
+// known yet. The caller must hold the write lock.
+func (om *RoarIndex[K, V]) keyIDOrAssign(key K) uint32 {
+	keyID, exists := om.backend.KeyID(key)
 	if !exists {
-		return nil, nil // No values associated
+		keyID = om.nextKeyID
+		om.nextKeyID++
+		om.backend.PutKey(key, keyID)
+		for _, index := range om.keyIndexes {
+			index.addKey(key)
+		}
 	}
+	return keyID
+}
 
-	values := make([]V, 0, bm.GetCardinality())
-	it := bm.Iterator()
-	for it.HasNext() {
-		valueID := it.Next()
-		value, valueExists := om.idToValue[valueID]
-		if valueExists {
-			values = append(values, value)
+// valueIDOrAssign returns the ID of value, assigning a new one when it is
+// not known yet. The caller must hold the write lock.
+func (om *RoarIndex[K, V]) valueIDOrAssign(value V) uint32 {
+	valueID, exists := om.backend.ValueID(value)
+	if !exists {
+		valueID = om.nextValueID
+		om.nextValueID++
+		om.backend.PutValue(value, valueID)
+		for _, index := range om.valueIndexes {
+			index.addValue(valueID, value)
 		}
 	}
+	return valueID
+}
 
-	return values, nil
+// GetMap retrieves the set of values associated with a key.
+func (om *RoarIndex[K, V]) GetMap(key K) ([]V, error) {
+	return om.GetMapCtx(context.Background(), key)
 }
 
 // HasValue checks if a value is associated with a key.
@@ -107,17 +157,17 @@ func (om *RoarIndex[K, V]) HasValue(key K, value V) bool {
 	om.mtx.RLock()
 	defer om.mtx.RUnlock()
 
-	keyID, keyExists := om.keyToID[key]
+	keyID, keyExists := om.backend.KeyID(key)
 	if !keyExists {
 		return false
 	}
 
-	valueID, valueExists := om.valueToID[value]
+	valueID, valueExists := om.backend.ValueID(value)
 	if !valueExists {
 		return false
 	}
 
-	bm, exists := om.data[keyID]
+	bm, exists := om.backend.Bitmap(keyID)
 	if !exists {
 		return false
 	}
@@ -130,38 +180,45 @@ func (om *RoarIndex[K, V]) DeleteMap(key K) {
 	om.mtx.Lock()
 	defer om.mtx.Unlock()
 
-	keyID, keyExists := om.keyToID[key]
+	om.deleteLocked(key)
+}
+
+// deleteLocked removes key and reports whether it was in the RoarIndex, the
+// caller must hold the write lock.
+func (om *RoarIndex[K, V]) deleteLocked(key K) bool {
+	keyID, keyExists := om.backend.KeyID(key)
 	if !keyExists {
-		return
+		return false
+	}
+
+	if om.reverse != nil {
+		if bm, exists := om.backend.Bitmap(keyID); exists {
+			om.reverseRemove(keyID, bm)
+		}
 	}
 
 	// Remove the bitmap and key mappings
-	delete(om.data, keyID)
-	delete(om.keyToID, key)
-	delete(om.idToKey, keyID)
+	om.backend.DeleteBitmap(keyID)
+	om.backend.DeleteKey(key, keyID)
+	om.dirty.Remove(keyID)
+	if om.sequences != nil {
+		delete(om.sequences, keyID)
+	}
+	for _, index := range om.keyIndexes {
+		index.removeKey(key)
+	}
+	return true
 }
 
 // Keys returns a slice of all keys in the RoarIndex.
 func (om *RoarIndex[K, V]) Keys() []K {
-	om.mtx.RLock()
-	defer om.mtx.RUnlock()
-
-	keys := make([]K, 0, len(om.keyToID))
-	for key := range om.keyToID {
-		keys = append(keys, key)
-	}
+	keys, _ := om.KeysCtx(context.Background())
 	return keys
 }
 
 // Values returns a slice of all values in the RoarIndex.
 func (om *RoarIndex[K, V]) Values() []V {
-	om.mtx.RLock()
-	defer om.mtx.RUnlock()
-
-	values := make([]V, 0, len(om.valueToID))
-	for value := range om.valueToID {
-		values = append(values, value)
-	}
+	values, _ := om.ValuesCtx(context.Background())
 	return values
 }
 
@@ -170,5 +227,5 @@ func (om *RoarIndex[K, V]) Count() int {
 	om.mtx.RLock()
 	defer om.mtx.RUnlock()
 
-	return len(om.keyToID)
+	return om.backend.KeyCount()
 }
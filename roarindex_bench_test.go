@@ -3,6 +3,7 @@ package roarindex
 import (
 	"fmt"
 	"runtime"
+	"sync"
 	"testing"
 )
 
@@ -31,6 +32,79 @@ func BenchmarkRoarIndexGetMap(b *testing.B) {
 	}
 }
 
+func BenchmarkRoarIndexAppendMap(b *testing.B) {
+	om := NewRoarIndex[string, int]()
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("map%d", i)
+		for j := 0; j < 10; j++ {
+			om.PushMap(keys[i], j)
+		}
+	}
+	var buf []int
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, _ = om.AppendMap(buf[:0], keys[i%1000])
+	}
+}
+
+func BenchmarkRoarIndexForEach(b *testing.B) {
+	om := NewRoarIndex[string, int]()
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("map%d", i)
+		for j := 0; j < 10; j++ {
+			om.PushMap(keys[i], j)
+		}
+	}
+	sum := 0
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		om.ForEach(keys[i%1000], func(v int) bool {
+			sum += v
+			return true
+		})
+	}
+}
+
+// BenchmarkRoarIndexGetMapLoop resolves 100 keys per op with GetMap, as a
+// baseline for BenchmarkRoarIndexGetMaps.
+func BenchmarkRoarIndexGetMapLoop(b *testing.B) {
+	om := NewRoarIndex[string, int]()
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("map%d", i)
+		for j := 0; j < 10; j++ {
+			om.PushMap(keys[i], j)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		start := (i * 100) % 1000
+		for _, key := range keys[start : start+100] {
+			om.GetMap(key)
+		}
+	}
+}
+
+func BenchmarkRoarIndexGetMaps(b *testing.B) {
+	om := NewRoarIndex[string, int]()
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("map%d", i)
+		for j := 0; j < 10; j++ {
+			om.PushMap(keys[i], j)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		start := (i * 100) % 1000
+		om.GetMaps(keys[start : start+100])
+	}
+}
+
 func BenchmarkRoarIndexHasValue(b *testing.B) {
 	om := NewRoarIndex[string, int]()
 	for i := 0; i < 1000; i++ {
@@ -47,6 +121,22 @@ func BenchmarkRoarIndexHasValue(b *testing.B) {
 	}
 }
 
+func BenchmarkRoarIndexHasValues(b *testing.B) {
+	om := NewRoarIndex[string, int]()
+	for i := 0; i < 1000; i++ {
+		mapID := fmt.Sprintf("map%d", i)
+		for j := 0; j < 10; j++ {
+			om.PushMap(mapID, j)
+		}
+	}
+	values := []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mapID := fmt.Sprintf("map%d", i%1000)
+		om.HasValues(mapID, values)
+	}
+}
+
 func BenchmarkRoarIndexDeleteMap(b *testing.B) {
 	om := NewRoarIndex[string, int]()
 	for i := 0; i < 1000; i++ {
@@ -62,47 +152,121 @@ func BenchmarkRoarIndexDeleteMap(b *testing.B) {
 	}
 }
 
+// liveHeapGrowth returns the MB of live heap the result of build keeps,
+// collecting garbage before and after so that sub-benchmarks measured with
+// it are comparable.
+func liveHeapGrowth(build func() any) float64 {
+	var m runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	before := int64(m.Alloc)
+
+	result := build()
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	runtime.KeepAlive(result)
+	return float64(int64(m.Alloc)-before) / 1024 / 1024
+}
+
 func BenchmarkMemoryUsageComparison(b *testing.B) {
 	const numKeys = 10_000
 	const numValues = 50_000
 
 	b.Run(fmt.Sprintf("RoarIndex-%d-kv", numKeys*numValues), func(b *testing.B) {
-		var m runtime.MemStats
-		runtime.ReadMemStats(&m)
-		beforeAlloc := m.Alloc
-
-		om := NewRoarIndex[string, int]()
-		// Insert 10,000 keys with ~50,000 values each (500,000,000 total values)
-		for i := 0; i < numKeys; i++ {
-			key := fmt.Sprintf("key%d", i)
-			for j := 0; j < numValues; j++ {
-				om.PushMap(key, j)
+		mb := liveHeapGrowth(func() any {
+			om := NewRoarIndex[string, int]()
+			// Insert 10,000 keys with ~50,000 values each (500,000,000 total values)
+			for i := 0; i < numKeys; i++ {
+				key := fmt.Sprintf("key%d", i)
+				for j := 0; j < numValues; j++ {
+					om.PushMap(key, j)
+				}
 			}
-		}
+			return om
+		})
+		b.ReportMetric(mb, "MB-RoarIndex")
+	})
 
-		runtime.ReadMemStats(&m)
-		b.ReportMetric(float64(m.Alloc-beforeAlloc)/1024/1024, "MB-RoarIndex")
+	b.Run(fmt.Sprintf("RoarIndexOptimized-%d-kv", numKeys*numValues), func(b *testing.B) {
+		mb := liveHeapGrowth(func() any {
+			om := NewRoarIndex[string, int]()
+			// Insert 10,000 keys with ~50,000 values each (500,000,000 total values)
+			for i := 0; i < numKeys; i++ {
+				key := fmt.Sprintf("key%d", i)
+				for j := 0; j < numValues; j++ {
+					om.PushMap(key, j)
+				}
+			}
+			om.Optimize()
+			return om
+		})
+		b.ReportMetric(mb, "MB-RoarIndexOptimized")
 	})
 
 	b.Run(fmt.Sprintf("StandardMap-%d-kv", numKeys*numValues), func(b *testing.B) {
-		var m runtime.MemStats
-		runtime.ReadMemStats(&m)
-		beforeAlloc := m.Alloc
-
-		standardMap := make(map[string][]int)
-		// Insert 10,000 keys with ~50,000 values each (500,000,000 total values)
-		for i := 0; i < numKeys; i++ {
-			key := fmt.Sprintf("key%d", i)
-			values := make([]int, 0, 5)
-			for j := 0; j < numValues; j++ {
-				values = append(values, j)
+		mb := liveHeapGrowth(func() any {
+			standardMap := make(map[string][]int)
+			// Insert 10,000 keys with ~50,000 values each (500,000,000 total values)
+			for i := 0; i < numKeys; i++ {
+				key := fmt.Sprintf("key%d", i)
+				values := make([]int, 0, 5)
+				for j := 0; j < numValues; j++ {
+					values = append(values, j)
+				}
+				standardMap[key] = values
 			}
-			standardMap[key] = values
+			return standardMap
+		})
+		b.ReportMetric(mb, "MB-StdMap")
+	})
+}
+
+func BenchmarkRoarIndexFacetCounts(b *testing.B) {
+	om := NewRoarIndex[string, int]()
+	facets := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		mapID := fmt.Sprintf("map%d", i)
+		for j := 0; j < 1000; j++ {
+			om.PushMap(mapID, (i*j)%10000)
 		}
+		facets = append(facets, mapID)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		om.FacetCounts(facets[i%1000], facets)
+	}
+}
 
-		runtime.ReadMemStats(&m)
-		b.ReportMetric(float64(m.Alloc-beforeAlloc)/1024/1024, "MB-StdMap")
+// BenchmarkBuilder loads b.N pairs with a growing number of workers, the
+// time per pair should drop close to linearly up to the number of CPUs.
+func BenchmarkBuilder(b *testing.B) {
+	b.Run("RoarIndexPushMap", func(b *testing.B) {
+		om := NewRoarIndex[int, int]()
+		for i := 0; i < b.N; i++ {
+			om.PushMap(i%100_000, i%1_000_000)
+		}
 	})
+
+	for _, workers := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("Workers%d", workers), func(b *testing.B) {
+			builder := NewBuilder[int, int]()
+			per := b.N/workers + 1
+
+			var wg sync.WaitGroup
+			for w := 0; w < workers; w++ {
+				worker := builder.Worker()
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := w * per; i < (w+1)*per; i++ {
+						worker.Add(i%100_000, i%1_000_000)
+					}
+				}()
+			}
+			wg.Wait()
+			builder.Build()
+		})
+	}
 }
 
 // ... existing code ...
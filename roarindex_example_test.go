@@ -2,7 +2,7 @@ package roarindex_test
 
 import (
 	"fmt"
-	"slices"
+	"strings"
 
 	"github.com/thisisdevelopment/roarindex"
 )
@@ -14,8 +14,6 @@ func ExampleRoarIndex() {
 	cm.PushMap("testMap", "value3")
 
 	values, _ := cm.GetMap("testMap")
-	// order is not guaranteed
-	slices.Sort(values)
 	fmt.Println(values)
 	// Output: [value1 value2 value3]
 }
@@ -31,15 +29,38 @@ func ExampleRoarIndex_Count() {
 
 func ExampleRoarIndex_Values() {
 	cm := roarindex.NewRoarIndex[string, string]()
-	cm.PushMap("testMap1", "value1")
-	cm.PushMap("testMap2", "value2")
+	cm.SetOrder(roarindex.OrderInsertion)
+	cm.PushMap("testMap1", "value2")
+	cm.PushMap("testMap2", "value1")
 	cm.PushMap("testMap3", "value2")
 
-	values := cm.Values()
-	// order is not guaranteed
-	slices.Sort(values)
-	fmt.Println(values)
-	// Output: [value1 value2]
+	fmt.Println(cm.Values())
+	// Output: [value2 value1]
+}
+
+func ExampleRoarIndex_Keys() {
+	cm := roarindex.NewRoarIndex[string, string]()
+	cm.SetOrder(roarindex.OrderInsertion)
+	cm.PushMap("testMap2", "value1")
+	cm.PushMap("testMap1", "value2")
+	cm.PushMap("testMap3", "value2")
+
+	fmt.Println(cm.Keys())
+	// Output: [testMap2 testMap1 testMap3]
+}
+
+func ExampleRoarIndex_SetOrderFunc() {
+	cm := roarindex.NewRoarIndex[string, string]()
+	cm.SetOrderFunc(strings.Compare, func(a, b string) int {
+		return strings.Compare(b, a)
+	})
+	cm.PushMap("testMap2", "value1")
+	cm.PushMap("testMap1", "value3")
+	cm.PushMap("testMap1", "value2")
+
+	values, _ := cm.GetMap("testMap1")
+	fmt.Println(cm.Keys(), cm.Values(), values)
+	// Output: [testMap1 testMap2] [value3 value2 value1] [value3 value2]
 }
 
 func ExampleRoarIndex_DeleteMap() {
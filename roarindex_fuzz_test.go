@@ -257,12 +257,12 @@ func FuzzRoarIndexInternalState(f *testing.F) {
 		om.mtx.RLock()
 		defer om.mtx.RUnlock()
 
-		if _, exists := om.keyToID[key]; exists {
+		if _, exists := om.backend.KeyID(key); exists {
 			t.Errorf("keyToID should not contain deleted key %q", key)
 		}
-		keyID, exists := om.keyToID[key]
+		keyID, exists := om.backend.KeyID(key)
 		if exists {
-			if _, exists := om.data[keyID]; exists {
+			if _, exists := om.backend.Bitmap(keyID); exists {
 				t.Errorf("data map should not contain deleted key ID")
 			}
 		}
@@ -0,0 +1,83 @@
+package roarindex
+
+import (
+	"errors"
+	"slices"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// ErrInsertionOrderDisabled is returned by GetMapOrdered when insertion
+// order is not being recorded.
+var ErrInsertionOrderDisabled = errors.New("insertion order not enabled")
+
+// EnableInsertionOrder starts recording the order in which values are
+// pushed to each key, for GetMapOrdered. Existing associations are taken to
+// have been pushed in value ID order. The order is kept in memory only.
+// Enabling it again is a no-op.
+func (om *RoarIndex[K, V]) EnableInsertionOrder() {
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	if om.sequences != nil {
+		return
+	}
+
+	om.sequences = make(map[uint32][]uint32)
+	om.backend.RangeBitmaps(func(keyID uint32, bm *roaring.Bitmap) bool {
+		om.sequences[keyID] = bm.ToArray()
+		return true
+	})
+}
+
+// DisableInsertionOrder stops recording insertion order and frees it.
+func (om *RoarIndex[K, V]) DisableInsertionOrder() {
+	om.mtx.Lock()
+	defer om.mtx.Unlock()
+
+	om.sequences = nil
+}
+
+// sequenceAdd records that valueID was newly pushed to keyID. The caller
+// must hold the write lock and have insertion order enabled.
+func (om *RoarIndex[K, V]) sequenceAdd(keyID, valueID uint32) {
+	om.sequences[keyID] = append(om.sequences[keyID], valueID)
+}
+
+// sequenceRemove drops the value IDs in bm from the sequence of keyID. The
+// caller must hold the write lock and have insertion order enabled.
+func (om *RoarIndex[K, V]) sequenceRemove(keyID uint32, bm *roaring.Bitmap) {
+	if bm.IsEmpty() {
+		return
+	}
+	om.sequences[keyID] = slices.DeleteFunc(om.sequences[keyID], bm.Contains)
+}
+
+// GetMapOrdered retrieves the values associated with key in the order they
+// were first pushed to it, or most recently pushed first when newestFirst
+// is set. Pushing a value the key already holds does not move it. It
+// requires EnableInsertionOrder.
+func (om *RoarIndex[K, V]) GetMapOrdered(key K, newestFirst bool) ([]V, error) {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	if om.sequences == nil {
+		return nil, ErrInsertionOrderDisabled
+	}
+	keyID, keyExists := om.backend.KeyID(key)
+	if !keyExists {
+		return nil, ErrKeyNotFound
+	}
+
+	sequence := om.sequences[keyID]
+	values := make([]V, 0, len(sequence))
+	for i := range sequence {
+		if newestFirst {
+			i = len(sequence) - 1 - i
+		}
+		if value, valueExists := om.backend.Value(sequence[i]); valueExists {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
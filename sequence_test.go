@@ -0,0 +1,91 @@
+package roarindex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoarIndexGetMapOrdered(t *testing.T) {
+	om := NewRoarIndex[string, string]()
+	if _, err := om.GetMapOrdered("feed", false); err != ErrInsertionOrderDisabled {
+		t.Errorf("Expected ErrInsertionOrderDisabled, but got %v", err)
+	}
+
+	// Existing associations are seeded in value ID order
+	om.PushMap("other", "liked")
+	om.PushMap("feed", "posted")
+	om.EnableInsertionOrder()
+	om.EnableInsertionOrder()
+
+	om.PushMap("feed", "commented")
+	om.PushMap("feed", "liked")
+	om.PushMap("feed", "posted") // already associated, keeps its position
+
+	tests := []struct {
+		name        string
+		newestFirst bool
+		expected    []string
+	}{
+		{"OldestFirst", false, []string{"posted", "commented", "liked"}},
+		{"NewestFirst", true, []string{"liked", "commented", "posted"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := om.GetMapOrdered("feed", tt.newestFirst)
+			if err != nil {
+				t.Fatalf("GetMapOrdered failed: %v", err)
+			}
+			if !reflect.DeepEqual(values, tt.expected) {
+				t.Errorf("Expected %v, but got %v", tt.expected, values)
+			}
+		})
+	}
+
+	// GetMap keeps value ID order and HasValue keeps working
+	if values, _ := om.GetMap("feed"); !reflect.DeepEqual(values, []string{"liked", "posted", "commented"}) {
+		t.Errorf("Expected GetMap in value ID order, but got %v", values)
+	}
+	if !om.HasValue("feed", "commented") {
+		t.Errorf("Expected feed to have commented")
+	}
+
+	if _, err := om.GetMapOrdered("nonExistent", false); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, but got %v", err)
+	}
+
+	om.DeleteMap("feed")
+	om.PushMap("feed", "shared")
+	if values, _ := om.GetMapOrdered("feed", false); !reflect.DeepEqual(values, []string{"shared"}) {
+		t.Errorf("Expected [shared] after deleting, but got %v", values)
+	}
+
+	om.DisableInsertionOrder()
+	if _, err := om.GetMapOrdered("feed", false); err != ErrInsertionOrderDisabled {
+		t.Errorf("Expected ErrInsertionOrderDisabled after disabling, but got %v", err)
+	}
+}
+
+func TestRoarIndexGetMapOrderedMerge(t *testing.T) {
+	om := NewRoarIndex[string, string]()
+	om.EnableInsertionOrder()
+	om.PushMap("feed", "c")
+	om.PushMap("feed", "a")
+
+	other := NewRoarIndex[string, string]()
+	other.PushMap("feed", "a")
+	other.PushMap("feed", "b")
+
+	if err := om.Merge(other, MergeUnion); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if values, _ := om.GetMapOrdered("feed", false); !reflect.DeepEqual(values, []string{"c", "a", "b"}) {
+		t.Errorf("Expected [c a b] after union, but got %v", values)
+	}
+
+	if err := om.Merge(other, MergeIntersect); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if values, _ := om.GetMapOrdered("feed", false); !reflect.DeepEqual(values, []string{"a", "b"}) {
+		t.Errorf("Expected [a b] after intersect, but got %v", values)
+	}
+}
@@ -0,0 +1,370 @@
+// Package server exposes named RoarIndex instances over HTTP with JSON
+// request and response bodies.
+//
+// Routes:
+//
+//	GET    /indexes                                 list index names
+//	PUT    /indexes/{index}                         create an index
+//	DELETE /indexes/{index}                         drop an index
+//	GET    /indexes/{index}/count                   number of keys
+//	GET    /indexes/{index}/keys                    keys, paginated
+//	GET    /indexes/{index}/values                  values, paginated
+//	GET    /indexes/{index}/keys/{key}              values of a key, paginated
+//	POST   /indexes/{index}/keys/{key}              push {"values": [...]}
+//	DELETE /indexes/{index}/keys/{key}              delete a key
+//	GET    /indexes/{index}/keys/{key}/has/{value}  whether key holds value
+//	GET    /indexes/{index}/union?key=a&key=b       values of any key
+//	GET    /indexes/{index}/intersect?key=a&key=b   values of all keys
+//	GET    /indexes/{index}/difference?key=a&key=b  values of the first key only
+//
+// Paginated routes accept offset and limit query parameters. Pushing to an
+// index that does not exist creates it.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/thisisdevelopment/roarindex"
+)
+
+// DefaultLimit is the page size used when a request does not set a limit.
+const DefaultLimit = 1000
+
+// ErrIndexNotFound is returned when a named index does not exist.
+var ErrIndexNotFound = errors.New("index not found")
+
+// errBadRequest is the base of errors caused by malformed requests.
+var errBadRequest = errors.New("bad request")
+
+// Server hosts named RoarIndex instances and serves them over HTTP.
+type Server struct {
+	mtx     sync.RWMutex
+	indexes map[string]*roarindex.RoarIndex[string, string]
+
+	mux *http.ServeMux
+}
+
+// New creates a new Server without any indexes.
+func New() *Server {
+	s := &Server{
+		indexes: make(map[string]*roarindex.RoarIndex[string, string]),
+		mux:     http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("GET /indexes", s.handleListIndexes)
+	s.mux.HandleFunc("PUT /indexes/{index}", s.handleCreateIndex)
+	s.mux.HandleFunc("DELETE /indexes/{index}", s.handleDropIndex)
+	s.mux.HandleFunc("GET /indexes/{index}/count", s.handleCount)
+	s.mux.HandleFunc("GET /indexes/{index}/keys", s.handleKeys)
+	s.mux.HandleFunc("GET /indexes/{index}/values", s.handleValues)
+	s.mux.HandleFunc("GET /indexes/{index}/keys/{key}", s.handleGet)
+	s.mux.HandleFunc("POST /indexes/{index}/keys/{key}", s.handlePush)
+	s.mux.HandleFunc("DELETE /indexes/{index}/keys/{key}", s.handleDelete)
+	s.mux.HandleFunc("GET /indexes/{index}/keys/{key}/has/{value}", s.handleHas)
+	s.mux.HandleFunc("GET /indexes/{index}/union", s.handleUnion)
+	s.mux.HandleFunc("GET /indexes/{index}/intersect", s.handleIntersect)
+	s.mux.HandleFunc("GET /indexes/{index}/difference", s.handleDifference)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Add registers an existing index under name, replacing any index with
+// the same name.
+func (s *Server) Add(name string, index *roarindex.RoarIndex[string, string]) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.indexes[name] = index
+}
+
+// Index returns the index registered under name.
+func (s *Server) Index(name string) (*roarindex.RoarIndex[string, string], error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	index, exists := s.indexes[name]
+	if !exists {
+		return nil, ErrIndexNotFound
+	}
+	return index, nil
+}
+
+// indexOrCreate returns the index registered under name, creating it when
+// it does not exist.
+func (s *Server) indexOrCreate(name string) *roarindex.RoarIndex[string, string] {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	index, exists := s.indexes[name]
+	if !exists {
+		index = roarindex.NewRoarIndex[string, string]()
+		s.indexes[name] = index
+	}
+	return index
+}
+
+type pushRequest struct {
+	Values []string `json:"values"`
+}
+
+type pageResponse struct {
+	Items  []string `json:"items"`
+	Total  int      `json:"total"`
+	Offset int      `json:"offset"`
+	Limit  int      `json:"limit"`
+}
+
+type countResponse struct {
+	Count int `json:"count"`
+}
+
+type hasResponse struct {
+	Has bool `json:"has"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) handleListIndexes(w http.ResponseWriter, r *http.Request) {
+	s.mtx.RLock()
+	names := make([]string, 0, len(s.indexes))
+	for name := range s.indexes {
+		names = append(names, name)
+	}
+	s.mtx.RUnlock()
+
+	slices.Sort(names)
+	writePage(w, r, names)
+}
+
+func (s *Server) handleCreateIndex(w http.ResponseWriter, r *http.Request) {
+	s.indexOrCreate(r.PathValue("index"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDropIndex(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("index")
+
+	s.mtx.Lock()
+	_, exists := s.indexes[name]
+	delete(s.indexes, name)
+	s.mtx.Unlock()
+
+	if !exists {
+		writeError(w, ErrIndexNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleCount(w http.ResponseWriter, r *http.Request) {
+	index, err := s.Index(r.PathValue("index"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, countResponse{Count: index.Count()})
+}
+
+func (s *Server) handleKeys(w http.ResponseWriter, r *http.Request) {
+	index, err := s.Index(r.PathValue("index"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	keys, err := index.KeysCtx(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// Keys come in random order, sort them so pages are stable
+	slices.Sort(keys)
+	writePage(w, r, keys)
+}
+
+func (s *Server) handleValues(w http.ResponseWriter, r *http.Request) {
+	index, err := s.Index(r.PathValue("index"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	values, err := index.ValuesCtx(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	slices.Sort(values)
+	writePage(w, r, values)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	index, err := s.Index(r.PathValue("index"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	values, err := index.GetMapCtx(r.Context(), r.PathValue("key"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, values)
+}
+
+func (s *Server) handlePush(w http.ResponseWriter, r *http.Request) {
+	var req pushRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, fmt.Errorf("%w: %v", errBadRequest, err))
+		return
+	}
+
+	index := s.indexOrCreate(r.PathValue("index"))
+	index.PushValues(r.PathValue("key"), req.Values...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	index, err := s.Index(r.PathValue("index"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if index.DeleteMaps(r.PathValue("key")) == 0 {
+		writeError(w, roarindex.ErrKeyNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleHas(w http.ResponseWriter, r *http.Request) {
+	index, err := s.Index(r.PathValue("index"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	has := index.HasValue(r.PathValue("key"), r.PathValue("value"))
+	writeJSON(w, http.StatusOK, hasResponse{Has: has})
+}
+
+func (s *Server) handleUnion(w http.ResponseWriter, r *http.Request) {
+	index, err := s.Index(r.PathValue("index"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	values, err := index.UnionCtx(r.Context(), r.URL.Query()["key"]...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, values)
+}
+
+func (s *Server) handleIntersect(w http.ResponseWriter, r *http.Request) {
+	index, err := s.Index(r.PathValue("index"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	values, err := index.IntersectCtx(r.Context(), r.URL.Query()["key"]...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, values)
+}
+
+func (s *Server) handleDifference(w http.ResponseWriter, r *http.Request) {
+	index, err := s.Index(r.PathValue("index"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	keys := r.URL.Query()["key"]
+	if len(keys) == 0 {
+		writeError(w, fmt.Errorf("%w: missing key", errBadRequest))
+		return
+	}
+	values, err := index.DifferenceCtx(r.Context(), keys[0], keys[1:]...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, values)
+}
+
+// writePage writes the page of items selected by the offset and limit query
+// parameters.
+func writePage(w http.ResponseWriter, r *http.Request, items []string) {
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	limit, err := queryInt(r, "limit", DefaultLimit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	start := min(offset, len(items))
+	end := start + min(limit, len(items)-start)
+	page := items[start:end]
+	if page == nil {
+		page = []string{}
+	}
+	writeJSON(w, http.StatusOK, pageResponse{Items: page, Total: len(items), Offset: offset, Limit: limit})
+}
+
+// queryInt parses a non-negative integer query parameter.
+func queryInt(r *http.Request, name string, def int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: invalid %s", errBadRequest, name)
+	}
+	return n, nil
+}
+
+// writeError writes err with a status code matching it.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, roarindex.ErrKeyNotFound), errors.Is(err, ErrIndexNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, errBadRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
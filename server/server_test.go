@@ -0,0 +1,176 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func do(t *testing.T, s *Server, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.NewDecoder(rec.Body).Decode(&v); err != nil {
+		t.Fatalf("Expected a JSON body, got error: %v", err)
+	}
+	return v
+}
+
+func TestServerPushAndGet(t *testing.T) {
+	s := New()
+
+	rec := do(t, s, http.MethodPost, "/indexes/idx/keys/key1", `{"values": ["value1", "value2", "value3"]}`)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204 on push, got %d", rec.Code)
+	}
+
+	rec = do(t, s, http.MethodGet, "/indexes/idx/keys/key1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 on get, got %d", rec.Code)
+	}
+	page := decode[pageResponse](t, rec)
+	if !reflect.DeepEqual(page.Items, []string{"value1", "value2", "value3"}) || page.Total != 3 {
+		t.Errorf("Expected all values, got %+v", page)
+	}
+
+	rec = do(t, s, http.MethodGet, "/indexes/idx/keys/key1?offset=1&limit=1", "")
+	page = decode[pageResponse](t, rec)
+	if !reflect.DeepEqual(page.Items, []string{"value2"}) || page.Total != 3 || page.Offset != 1 || page.Limit != 1 {
+		t.Errorf("Expected the second value, got %+v", page)
+	}
+
+	rec = do(t, s, http.MethodGet, "/indexes/idx/keys/key1?offset=10", "")
+	page = decode[pageResponse](t, rec)
+	if len(page.Items) != 0 || page.Items == nil {
+		t.Errorf("Expected an empty page past the end, got %+v", page)
+	}
+
+	rec = do(t, s, http.MethodGet, "/indexes/idx/keys/key1?limit=-1", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 on negative limit, got %d", rec.Code)
+	}
+}
+
+func TestServerNotFound(t *testing.T) {
+	s := New()
+	do(t, s, http.MethodPost, "/indexes/idx/keys/key1", `{"values": ["value1"]}`)
+
+	rec := do(t, s, http.MethodGet, "/indexes/idx/keys/nonExistent", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 on a missing key, got %d", rec.Code)
+	}
+	if body := decode[errorResponse](t, rec); body.Error != "key not found" {
+		t.Errorf("Expected key not found error, got %q", body.Error)
+	}
+
+	rec = do(t, s, http.MethodGet, "/indexes/nonExistent/count", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 on a missing index, got %d", rec.Code)
+	}
+
+	rec = do(t, s, http.MethodDelete, "/indexes/nonExistent", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 dropping a missing index, got %d", rec.Code)
+	}
+}
+
+func TestServerHasDeleteAndCount(t *testing.T) {
+	s := New()
+	do(t, s, http.MethodPost, "/indexes/idx/keys/key1", `{"values": ["value1"]}`)
+	do(t, s, http.MethodPost, "/indexes/idx/keys/key2", `{"values": ["value/2"]}`)
+
+	rec := do(t, s, http.MethodGet, "/indexes/idx/keys/key2/has/value%2F2", "")
+	if body := decode[hasResponse](t, rec); !body.Has {
+		t.Errorf("Expected key2 to hold value/2")
+	}
+	rec = do(t, s, http.MethodGet, "/indexes/idx/keys/key1/has/value2", "")
+	if body := decode[hasResponse](t, rec); body.Has {
+		t.Errorf("Expected key1 not to hold value2")
+	}
+
+	rec = do(t, s, http.MethodGet, "/indexes/idx/count", "")
+	if body := decode[countResponse](t, rec); body.Count != 2 {
+		t.Errorf("Expected 2 keys, got %d", body.Count)
+	}
+
+	rec = do(t, s, http.MethodDelete, "/indexes/idx/keys/key1", "")
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected 204 on delete, got %d", rec.Code)
+	}
+	rec = do(t, s, http.MethodDelete, "/indexes/idx/keys/key1", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 on deleting a missing key, got %d", rec.Code)
+	}
+	rec = do(t, s, http.MethodGet, "/indexes/idx/keys", "")
+	if page := decode[pageResponse](t, rec); !reflect.DeepEqual(page.Items, []string{"key2"}) {
+		t.Errorf("Expected only key2, got %v", page.Items)
+	}
+	rec = do(t, s, http.MethodGet, "/indexes/idx/values", "")
+	if page := decode[pageResponse](t, rec); !reflect.DeepEqual(page.Items, []string{"value/2", "value1"}) {
+		t.Errorf("Expected all values, got %v", page.Items)
+	}
+}
+
+func TestServerSetOperations(t *testing.T) {
+	s := New()
+	do(t, s, http.MethodPost, "/indexes/idx/keys/a", `{"values": ["1", "2", "3"]}`)
+	do(t, s, http.MethodPost, "/indexes/idx/keys/b", `{"values": ["2", "3", "4"]}`)
+
+	tests := []struct {
+		target   string
+		expected []string
+	}{
+		{"/indexes/idx/union?key=a&key=b", []string{"1", "2", "3", "4"}},
+		{"/indexes/idx/intersect?key=a&key=b", []string{"2", "3"}},
+		{"/indexes/idx/difference?key=a&key=b", []string{"1"}},
+	}
+	for _, test := range tests {
+		rec := do(t, s, http.MethodGet, test.target, "")
+		if page := decode[pageResponse](t, rec); !reflect.DeepEqual(page.Items, test.expected) {
+			t.Errorf("Expected %v for %s, got %v", test.expected, test.target, page.Items)
+		}
+	}
+
+	rec := do(t, s, http.MethodGet, "/indexes/idx/difference", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 on difference without keys, got %d", rec.Code)
+	}
+}
+
+func TestServerIndexes(t *testing.T) {
+	s := New()
+
+	rec := do(t, s, http.MethodPut, "/indexes/b", "")
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected 204 on create, got %d", rec.Code)
+	}
+	do(t, s, http.MethodPut, "/indexes/a", "")
+
+	rec = do(t, s, http.MethodGet, "/indexes", "")
+	if page := decode[pageResponse](t, rec); !reflect.DeepEqual(page.Items, []string{"a", "b"}) {
+		t.Errorf("Expected indexes a and b, got %v", page.Items)
+	}
+
+	rec = do(t, s, http.MethodDelete, "/indexes/a", "")
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected 204 on drop, got %d", rec.Code)
+	}
+	if _, err := s.Index("a"); err != ErrIndexNotFound {
+		t.Errorf("Expected index a to be dropped, got %v", err)
+	}
+
+	rec = do(t, s, http.MethodPost, "/indexes/b/keys/key1", `{"values": `)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 on a malformed body, got %d", rec.Code)
+	}
+}
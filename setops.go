@@ -0,0 +1,49 @@
+package roarindex
+
+import (
+	"context"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// Union returns the values associated with any of the keys. Keys that are
+// not in the RoarIndex count as empty sets.
+func (om *RoarIndex[K, V]) Union(keys ...K) []V {
+	values, _ := om.UnionCtx(context.Background(), keys...)
+	return values
+}
+
+// Intersect returns the values associated with all of the keys. Keys that
+// are not in the RoarIndex count as empty sets.
+func (om *RoarIndex[K, V]) Intersect(keys ...K) []V {
+	values, _ := om.IntersectCtx(context.Background(), keys...)
+	return values
+}
+
+// Difference returns the values associated with key but with none of the
+// others. Keys that are not in the RoarIndex count as empty sets.
+func (om *RoarIndex[K, V]) Difference(key K, others ...K) []V {
+	values, _ := om.DifferenceCtx(context.Background(), key, others...)
+	return values
+}
+
+// bitmapsOf returns the bitmaps of the keys that are in the RoarIndex. The
+// caller must hold the lock.
+func (om *RoarIndex[K, V]) bitmapsOf(keys []K) []*roaring.Bitmap {
+	bitmaps := make([]*roaring.Bitmap, 0, len(keys))
+	for _, key := range keys {
+		if keyID, keyExists := om.backend.KeyID(key); keyExists {
+			if bm, exists := om.backend.Bitmap(keyID); exists {
+				bitmaps = append(bitmaps, bm)
+			}
+		}
+	}
+	return bitmaps
+}
+
+// valuesOf translates a bitmap of value IDs to values. The caller must hold
+// the lock.
+func (om *RoarIndex[K, V]) valuesOf(bm *roaring.Bitmap) []V {
+	values, _ := om.valuesOfCtx(context.Background(), bm)
+	return values
+}
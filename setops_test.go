@@ -0,0 +1,41 @@
+package roarindex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoarIndexSetOperations(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	for _, v := range []int{1, 2, 3, 4} {
+		om.PushMap("map1", v)
+	}
+	for _, v := range []int{3, 4, 5} {
+		om.PushMap("map2", v)
+	}
+	for _, v := range []int{4, 6} {
+		om.PushMap("map3", v)
+	}
+
+	if result := om.Union("map1", "map2", "nonExistent"); !reflect.DeepEqual(result, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected union [1 2 3 4 5], but got %v", result)
+	}
+	if result := om.Intersect("map1", "map2", "map3"); !reflect.DeepEqual(result, []int{4}) {
+		t.Errorf("Expected intersection [4], but got %v", result)
+	}
+	if result := om.Intersect("map1", "nonExistent"); len(result) != 0 {
+		t.Errorf("Expected empty intersection with a missing key, but got %v", result)
+	}
+	if result := om.Difference("map1", "map2"); !reflect.DeepEqual(result, []int{1, 2}) {
+		t.Errorf("Expected difference [1 2], but got %v", result)
+	}
+	if result := om.Difference("map1"); !reflect.DeepEqual(result, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected difference [1 2 3 4], but got %v", result)
+	}
+	if result := om.Difference("nonExistent", "map1"); len(result) != 0 {
+		t.Errorf("Expected empty difference of a missing key, but got %v", result)
+	}
+	if result := om.Union(); len(result) != 0 {
+		t.Errorf("Expected empty union of no keys, but got %v", result)
+	}
+}
@@ -0,0 +1,147 @@
+package roarindex
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// ErrUnknownMetric is returned when a SimilarityMetric is not supported.
+var ErrUnknownMetric = errors.New("unknown similarity metric")
+
+// SimilarityMetric selects how the similarity between two keys' value sets
+// is scored. All metrics range from 0 (disjoint) to 1 (identical).
+type SimilarityMetric int
+
+const (
+	// Jaccard scores |A ∩ B| / |A ∪ B|.
+	Jaccard SimilarityMetric = iota
+	// Overlap scores |A ∩ B| / min(|A|, |B|).
+	Overlap
+	// Cosine scores |A ∩ B| / sqrt(|A| * |B|).
+	Cosine
+)
+
+// KeyScore pairs a key with its similarity score.
+type KeyScore[K comparable] struct {
+	Key   K
+	Score float64
+}
+
+// Similarity scores how similar the value sets of keys a and b are.
+func (om *RoarIndex[K, V]) Similarity(a, b K, metric SimilarityMetric) (float64, error) {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bmA, err := om.bitmapOf(a)
+	if err != nil {
+		return 0, err
+	}
+	bmB, err := om.bitmapOf(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return similarity(bmA, bmB, metric)
+}
+
+// MostSimilar returns up to n other keys most similar to key, highest score
+// first. Keys with equal scores are ordered by insertion, keys sharing no
+// values are left out. When the reverse index is enabled only keys sharing
+// at least one value with key are scanned.
+func (om *RoarIndex[K, V]) MostSimilar(key K, n int, metric SimilarityMetric) ([]KeyScore[K], error) {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	bm, err := om.bitmapOf(key)
+	if err != nil {
+		return nil, err
+	}
+	if metric < Jaccard || metric > Cosine {
+		return nil, ErrUnknownMetric
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+
+	keyID, _ := om.backend.KeyID(key)
+	type candidate struct {
+		keyID uint32
+		score float64
+	}
+	var candidates []candidate
+	score := func(otherID uint32, other *roaring.Bitmap) {
+		if otherID == keyID {
+			return
+		}
+		if s, _ := similarity(bm, other, metric); s > 0 {
+			candidates = append(candidates, candidate{keyID: otherID, score: s})
+		}
+	}
+
+	if om.reverse != nil {
+		it := om.candidateKeys(bm).Iterator()
+		for it.HasNext() {
+			otherID := it.Next()
+			if other, exists := om.backend.Bitmap(otherID); exists {
+				score(otherID, other)
+			}
+		}
+	} else {
+		om.backend.RangeBitmaps(func(otherID uint32, other *roaring.Bitmap) bool {
+			score(otherID, other)
+			return true
+		})
+	}
+
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.keyID, b.keyID)
+	})
+
+	result := make([]KeyScore[K], 0, min(n, len(candidates)))
+	for _, c := range candidates[:min(n, len(candidates))] {
+		key, _ := om.backend.Key(c.keyID)
+		result = append(result, KeyScore[K]{Key: key, Score: c.score})
+	}
+	return result, nil
+}
+
+// bitmapOf returns the bitmap of a key, which is empty when the key has no
+// values. The caller must hold the lock.
+func (om *RoarIndex[K, V]) bitmapOf(key K) (*roaring.Bitmap, error) {
+	keyID, keyExists := om.backend.KeyID(key)
+	if !keyExists {
+		return nil, ErrKeyNotFound
+	}
+
+	bm, exists := om.backend.Bitmap(keyID)
+	if !exists {
+		return roaring.NewBitmap(), nil
+	}
+	return bm, nil
+}
+
+func similarity(a, b *roaring.Bitmap, metric SimilarityMetric) (float64, error) {
+	var denominator float64
+	switch metric {
+	case Jaccard:
+		denominator = float64(a.OrCardinality(b))
+	case Overlap:
+		denominator = float64(min(a.GetCardinality(), b.GetCardinality()))
+	case Cosine:
+		denominator = math.Sqrt(float64(a.GetCardinality()) * float64(b.GetCardinality()))
+	default:
+		return 0, ErrUnknownMetric
+	}
+
+	if denominator == 0 {
+		return 0, nil
+	}
+	return float64(a.AndCardinality(b)) / denominator, nil
+}
@@ -0,0 +1,97 @@
+package roarindex
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func newSimilarityIndex() *RoarIndex[string, string] {
+	om := NewRoarIndex[string, string]()
+	for _, item := range []string{"a", "b", "c", "d"} {
+		om.PushMap("user1", item)
+	}
+	for _, item := range []string{"a", "b", "c", "d"} {
+		om.PushMap("user2", item)
+	}
+	for _, item := range []string{"a", "b"} {
+		om.PushMap("user3", item)
+	}
+	for _, item := range []string{"c", "e", "f"} {
+		om.PushMap("user4", item)
+	}
+	om.PushMap("user5", "g")
+	return om
+}
+
+func TestRoarIndexSimilarity(t *testing.T) {
+	om := newSimilarityIndex()
+
+	tests := []struct {
+		a, b     string
+		metric   SimilarityMetric
+		expected float64
+	}{
+		{"user1", "user2", Jaccard, 1},
+		{"user1", "user3", Jaccard, 0.5},
+		{"user1", "user3", Overlap, 1},
+		{"user1", "user3", Cosine, 2 / math.Sqrt(8)},
+		{"user1", "user4", Jaccard, 1.0 / 6},
+		{"user1", "user5", Cosine, 0},
+	}
+	for _, test := range tests {
+		result, err := om.Similarity(test.a, test.b, test.metric)
+		if err != nil {
+			t.Errorf("Expected similarity of %s and %s, but got error: %s", test.a, test.b, err.Error())
+		}
+		if math.Abs(result-test.expected) > 1e-9 {
+			t.Errorf("Expected similarity of %s and %s to be %f, but got %f", test.a, test.b, test.expected, result)
+		}
+	}
+
+	if _, err := om.Similarity("user1", "nonExistent", Jaccard); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, but got %v", err)
+	}
+	if _, err := om.Similarity("user1", "user2", SimilarityMetric(42)); err != ErrUnknownMetric {
+		t.Errorf("Expected ErrUnknownMetric, but got %v", err)
+	}
+}
+
+func TestRoarIndexMostSimilar(t *testing.T) {
+	expected := []KeyScore[string]{{"user2", 1}, {"user3", 0.5}, {"user4", 1.0 / 6}}
+
+	om := newSimilarityIndex()
+	result, err := om.MostSimilar("user1", 10, Jaccard)
+	if err != nil {
+		t.Errorf("Expected user1 to exist, but it doesn't, error: %s", err.Error())
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	// The reverse index yields the same result
+	om.EnableReverseIndex()
+	result, _ = om.MostSimilar("user1", 10, Jaccard)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v with reverse index, but got %v", expected, result)
+	}
+
+	// The reverse index follows pushes and deletes
+	om.PushMap("user6", "a")
+	om.DeleteMap("user2")
+	result, _ = om.MostSimilar("user1", 2, Overlap)
+	expected = []KeyScore[string]{{"user3", 1}, {"user6", 1}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	om.DisableReverseIndex()
+	result, _ = om.MostSimilar("user1", 2, Overlap)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v without reverse index, but got %v", expected, result)
+	}
+
+	if _, err := om.MostSimilar("nonExistent", 2, Jaccard); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, but got %v", err)
+	}
+}
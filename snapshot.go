@@ -0,0 +1,183 @@
+package roarindex
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// ErrInvalidSnapshot is returned when reading data that is not a snapshot
+// written by WriteTo or an export written by ExportColumnar.
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// snapshotMagic starts every snapshot, the last byte is the format version.
+var snapshotMagic = []byte("ROARIDX\x01")
+
+// snapshotChunkSize is the number of entries per encoded chunk.
+const snapshotChunkSize = 1024
+
+// snapshotHeader follows the magic bytes and tells how many entries follow.
+type snapshotHeader struct {
+	NextKeyID   uint32
+	NextValueID uint32
+	Keys        int
+	Values      int
+}
+
+type snapshotValue[V comparable] struct {
+	ID    uint32
+	Value V
+}
+
+type snapshotKey[K comparable] struct {
+	ID     uint32
+	Key    K
+	Bitmap []byte
+}
+
+// WriteTo writes a snapshot of the RoarIndex to w. Keys and values are
+// encoded with encoding/gob, so K and V must be gob encodable.
+func (om *RoarIndex[K, V]) WriteTo(w io.Writer) (int64, error) {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	if _, err := bw.Write(snapshotMagic); err != nil {
+		return cw.n, err
+	}
+
+	enc := gob.NewEncoder(bw)
+	header := snapshotHeader{
+		NextKeyID:   om.nextKeyID,
+		NextValueID: om.nextValueID,
+		Keys:        om.backend.KeyCount(),
+		Values:      om.backend.ValueCount(),
+	}
+	if err := enc.Encode(header); err != nil {
+		return cw.n, err
+	}
+
+	var err error
+	values := make([]snapshotValue[V], 0, snapshotChunkSize)
+	om.backend.RangeValues(func(value V, valueID uint32) bool {
+		values = append(values, snapshotValue[V]{ID: valueID, Value: value})
+		if len(values) == snapshotChunkSize {
+			err = enc.Encode(values)
+			values = values[:0]
+		}
+		return err == nil
+	})
+	if err == nil && len(values) > 0 {
+		err = enc.Encode(values)
+	}
+	if err != nil {
+		return cw.n, err
+	}
+
+	var buf bytes.Buffer
+	keys := make([]snapshotKey[K], 0, snapshotChunkSize)
+	om.backend.RangeKeys(func(key K, keyID uint32) bool {
+		entry := snapshotKey[K]{ID: keyID, Key: key}
+		if bm, exists := om.backend.Bitmap(keyID); exists {
+			buf.Reset()
+			if _, err = bm.WriteTo(&buf); err != nil {
+				return false
+			}
+			entry.Bitmap = bytes.Clone(buf.Bytes())
+		}
+		keys = append(keys, entry)
+		if len(keys) == snapshotChunkSize {
+			err = enc.Encode(keys)
+			keys = keys[:0]
+		}
+		return err == nil
+	})
+	if err == nil && len(keys) > 0 {
+		err = enc.Encode(keys)
+	}
+	if err != nil {
+		return cw.n, err
+	}
+
+	err = bw.Flush()
+	return cw.n, err
+}
+
+// ReadRoarIndex reads a snapshot written by WriteTo into a new RoarIndex.
+func ReadRoarIndex[K comparable, V comparable](r io.Reader) (*RoarIndex[K, V], error) {
+	return ReadRoarIndexWithBackend(r, NewMemoryBackend[K, V]())
+}
+
+// ReadRoarIndexWithBackend reads a snapshot written by WriteTo into a new
+// RoarIndex stored in backend, which should be empty.
+func ReadRoarIndexWithBackend[K comparable, V comparable](r io.Reader, backend Backend[K, V]) (*RoarIndex[K, V], error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, snapshotMagic) {
+		return nil, ErrInvalidSnapshot
+	}
+
+	dec := gob.NewDecoder(br)
+	var header snapshotHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, errors.Join(ErrInvalidSnapshot, err)
+	}
+
+	// Bitmaps may only refer to values read before them
+	var nextValueID uint32
+	for read := 0; read < header.Values; {
+		var values []snapshotValue[V]
+		if err := dec.Decode(&values); err != nil {
+			return nil, errors.Join(ErrInvalidSnapshot, err)
+		}
+		for _, entry := range values {
+			backend.PutValue(entry.Value, entry.ID)
+			nextValueID = max(nextValueID, entry.ID+1)
+		}
+		read += len(values)
+	}
+
+	for read := 0; read < header.Keys; {
+		var keys []snapshotKey[K]
+		if err := dec.Decode(&keys); err != nil {
+			return nil, errors.Join(ErrInvalidSnapshot, err)
+		}
+		for _, entry := range keys {
+			backend.PutKey(entry.Key, entry.ID)
+			if entry.Bitmap == nil {
+				continue
+			}
+			bm := roaring.NewBitmap()
+			if err := bm.UnmarshalBinary(entry.Bitmap); err != nil {
+				return nil, errors.Join(ErrInvalidSnapshot, err)
+			}
+			if !bm.IsEmpty() && bm.Maximum() >= nextValueID {
+				return nil, ErrInvalidSnapshot
+			}
+			backend.PutBitmap(entry.ID, bm)
+		}
+		read += len(keys)
+	}
+
+	om := NewRoarIndexWithBackend(backend)
+	om.nextKeyID = max(om.nextKeyID, header.NextKeyID)
+	om.nextValueID = max(om.nextValueID, header.NextValueID)
+	return om, nil
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
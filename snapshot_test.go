@@ -0,0 +1,114 @@
+package roarindex
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+func TestRoarIndexSnapshotRoundTrip(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	for i := 0; i < snapshotChunkSize*2+5; i++ {
+		om.PushMap("map1", i)
+		om.PushMap("map2", i*2)
+	}
+	om.PushMap("map3", 1)
+	om.DeleteMap("map3")
+
+	var buf bytes.Buffer
+	n, err := om.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("Expected WriteTo to report %d bytes, got %d", buf.Len(), n)
+	}
+
+	restored, err := ReadRoarIndex[string, int](&buf)
+	if err != nil {
+		t.Fatalf("ReadRoarIndex failed: %v", err)
+	}
+	for _, key := range []string{"map1", "map2"} {
+		expected, _ := om.GetMap(key)
+		result, err := restored.GetMap(key)
+		if err != nil || !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %s to be restored, got %d values, error: %v", key, len(result), err)
+		}
+	}
+	if _, err := restored.GetMap("map3"); err != ErrKeyNotFound {
+		t.Errorf("Expected deleted map3 to stay deleted, got %v", err)
+	}
+	if restored.Stats() != om.Stats() {
+		t.Errorf("Expected stats %+v, got %+v", om.Stats(), restored.Stats())
+	}
+
+	// Deleted key IDs are not reused
+	restored.PushMap("map4", 1)
+	keyID, _ := restored.backend.KeyID("map4")
+	if keyID != 3 {
+		t.Errorf("Expected map4 to get key ID 3, got %d", keyID)
+	}
+}
+
+func TestRoarIndexSnapshotStructs(t *testing.T) {
+	type TestStruct struct {
+		ID   int
+		Name string
+	}
+	om := NewRoarIndex[int, TestStruct]()
+	om.PushMap(1, TestStruct{1, "one"})
+	om.PushMap(1, TestStruct{2, "two"})
+
+	var buf bytes.Buffer
+	if _, err := om.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	restored, err := ReadRoarIndex[int, TestStruct](&buf)
+	if err != nil {
+		t.Fatalf("ReadRoarIndex failed: %v", err)
+	}
+	if !restored.HasValue(1, TestStruct{2, "two"}) {
+		t.Errorf("Expected key 1 to hold struct 2")
+	}
+}
+
+func TestRoarIndexSnapshotInvalid(t *testing.T) {
+	if _, err := ReadRoarIndex[string, string](bytes.NewReader([]byte("not a snapshot"))); err != ErrInvalidSnapshot {
+		t.Errorf("Expected ErrInvalidSnapshot, got %v", err)
+	}
+
+	om := NewRoarIndex[string, string]()
+	om.PushMap("map1", "value1")
+	var buf bytes.Buffer
+	om.WriteTo(&buf)
+	if _, err := ReadRoarIndex[string, string](bytes.NewReader(buf.Bytes()[:buf.Len()-4])); err == nil {
+		t.Errorf("Expected a truncated snapshot to fail")
+	}
+
+	// A bitmap referring to a value ID that is not in the snapshot
+	buf.Reset()
+	buf.Write(snapshotMagic)
+	enc := gob.NewEncoder(&buf)
+	enc.Encode(snapshotHeader{NextKeyID: 1, NextValueID: 100, Keys: 1, Values: 1})
+	enc.Encode([]snapshotValue[string]{{ID: 0, Value: "value1"}})
+	bm, _ := roaring.BitmapOf(0, 99).MarshalBinary()
+	enc.Encode([]snapshotKey[string]{{ID: 0, Key: "map1", Bitmap: bm}})
+	if _, err := ReadRoarIndex[string, string](bytes.NewReader(buf.Bytes())); err != ErrInvalidSnapshot {
+		t.Errorf("Expected ErrInvalidSnapshot for an unknown value ID, got %v", err)
+	}
+}
+
+func TestRoarIndexStats(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	om.PushMap("map1", 1)
+	om.PushMap("map1", 2)
+	om.PushMap("map2", 2)
+
+	stats := om.Stats()
+	if stats.Keys != 2 || stats.Values != 2 || stats.Pairs != 3 || stats.BitmapBytes == 0 {
+		t.Errorf("Expected 2 keys, 2 values and 3 pairs, got %+v", stats)
+	}
+}
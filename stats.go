@@ -0,0 +1,34 @@
+package roarindex
+
+import (
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// Stats describes the size of a RoarIndex.
+type Stats struct {
+	// Keys is the number of keys.
+	Keys int
+	// Values is the number of distinct values.
+	Values int
+	// Pairs is the number of key-value associations.
+	Pairs uint64
+	// BitmapBytes is the serialized size of all bitmaps.
+	BitmapBytes uint64
+}
+
+// Stats returns statistics about the RoarIndex.
+func (om *RoarIndex[K, V]) Stats() Stats {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	stats := Stats{
+		Keys:   om.backend.KeyCount(),
+		Values: om.backend.ValueCount(),
+	}
+	om.backend.RangeBitmaps(func(_ uint32, bm *roaring.Bitmap) bool {
+		stats.Pairs += bm.GetCardinality()
+		stats.BitmapBytes += bm.GetSerializedSizeInBytes()
+		return true
+	})
+	return stats
+}
@@ -0,0 +1,137 @@
+package roarindex
+
+import (
+	"container/heap"
+
+	roaring "github.com/RoaringBitmap/roaring"
+)
+
+// KeyCount pairs a key with the number of values associated with it.
+type KeyCount[K comparable] struct {
+	Key   K
+	Count uint64
+}
+
+// ValueCount pairs a value with the number of keys it is associated with.
+type ValueCount[V comparable] struct {
+	Value V
+	Count uint64
+}
+
+// rankEntry is a candidate in a top-k selection. Ties on count are broken
+// by ID, lower IDs (inserted earlier) ranking higher.
+type rankEntry struct {
+	id    uint32
+	count uint64
+}
+
+func (e rankEntry) ranksBelow(other rankEntry) bool {
+	if e.count != other.count {
+		return e.count < other.count
+	}
+	return e.id > other.id
+}
+
+// rankHeap is a min-heap keeping the lowest ranked entry on top, so it can
+// be evicted when a better candidate shows up.
+type rankHeap []rankEntry
+
+func (h rankHeap) Len() int { return len(h) }
+
+func (h rankHeap) Less(i, j int) bool { return h[i].ranksBelow(h[j]) }
+
+func (h rankHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *rankHeap) Push(x any) { *h = append(*h, x.(rankEntry)) }
+
+func (h *rankHeap) Pop() any {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	*h = old[:n-1]
+	return entry
+}
+
+// offer adds an entry to a heap holding at most k entries.
+func (h *rankHeap) offer(entry rankEntry, k int) {
+	if h.Len() < k {
+		heap.Push(h, entry)
+		return
+	}
+	if (*h)[0].ranksBelow(entry) {
+		(*h)[0] = entry
+		heap.Fix(h, 0)
+	}
+}
+
+// ranked drains the heap and returns its entries from highest to lowest rank.
+func (h *rankHeap) ranked() []rankEntry {
+	entries := make([]rankEntry, h.Len())
+	for i := len(entries) - 1; i >= 0; i-- {
+		entries[i] = heap.Pop(h).(rankEntry)
+	}
+	return entries
+}
+
+// TopKeys returns up to k keys with the most values, largest first. Keys
+// with equal counts are ordered by insertion.
+func (om *RoarIndex[K, V]) TopKeys(k int) []KeyCount[K] {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	if k <= 0 {
+		return nil
+	}
+
+	h := make(rankHeap, 0, min(k, om.backend.KeyCount()))
+	om.backend.RangeBitmaps(func(keyID uint32, bm *roaring.Bitmap) bool {
+		h.offer(rankEntry{id: keyID, count: bm.GetCardinality()}, k)
+		return true
+	})
+
+	entries := h.ranked()
+	result := make([]KeyCount[K], 0, len(entries))
+	for _, entry := range entries {
+		key, _ := om.backend.Key(entry.id)
+		result = append(result, KeyCount[K]{Key: key, Count: entry.count})
+	}
+	return result
+}
+
+// TopValues returns up to k values associated with the most keys, most
+// common first. Values with equal counts are ordered by insertion.
+func (om *RoarIndex[K, V]) TopValues(k int) []ValueCount[V] {
+	om.mtx.RLock()
+	defer om.mtx.RUnlock()
+
+	if k <= 0 {
+		return nil
+	}
+
+	counts := make([]uint64, om.nextValueID)
+	buf := make([]uint32, 256)
+	om.backend.RangeBitmaps(func(_ uint32, bm *roaring.Bitmap) bool {
+		it := bm.ManyIterator()
+		for n := it.NextMany(buf); n > 0; n = it.NextMany(buf) {
+			for _, valueID := range buf[:n] {
+				counts[valueID]++
+			}
+		}
+		return true
+	})
+
+	h := make(rankHeap, 0, min(k, len(counts)))
+	for valueID, count := range counts {
+		if count > 0 {
+			h.offer(rankEntry{id: uint32(valueID), count: count}, k)
+		}
+	}
+
+	entries := h.ranked()
+	result := make([]ValueCount[V], 0, len(entries))
+	for _, entry := range entries {
+		value, _ := om.backend.Value(entry.id)
+		result = append(result, ValueCount[V]{Value: value, Count: entry.count})
+	}
+	return result
+}
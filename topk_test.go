@@ -0,0 +1,59 @@
+package roarindex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoarIndexTopKeys(t *testing.T) {
+	om := NewRoarIndex[string, int]()
+	om.PushMap("map1", 1)
+	om.PushMap("map2", 1)
+	om.PushMap("map2", 2)
+	om.PushMap("map3", 1)
+	om.PushMap("map3", 2)
+	om.PushMap("map3", 3)
+	om.PushMap("map4", 4)
+
+	expected := []KeyCount[string]{{"map3", 3}, {"map2", 2}, {"map1", 1}}
+	if result := om.TopKeys(3); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	// Ties are broken by insertion order
+	expected = []KeyCount[string]{{"map3", 3}, {"map2", 2}, {"map1", 1}, {"map4", 1}}
+	if result := om.TopKeys(10); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if result := om.TopKeys(0); len(result) != 0 {
+		t.Errorf("Expected no keys, but got %v", result)
+	}
+}
+
+func TestRoarIndexTopValues(t *testing.T) {
+	om := NewRoarIndex[string, string]()
+	om.PushMap("map1", "value1")
+	om.PushMap("map1", "value2")
+	om.PushMap("map1", "value3")
+	om.PushMap("map2", "value3")
+	om.PushMap("map2", "value2")
+	om.PushMap("map3", "value3")
+	om.PushMap("map3", "value4")
+
+	expected := []ValueCount[string]{{"value3", 3}, {"value2", 2}}
+	if result := om.TopValues(2); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	// Values of deleted keys are no longer counted
+	om.DeleteMap("map3")
+	expected = []ValueCount[string]{{"value2", 2}, {"value3", 2}, {"value1", 1}}
+	if result := om.TopValues(10); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if result := om.TopValues(-1); len(result) != 0 {
+		t.Errorf("Expected no values, but got %v", result)
+	}
+}